@@ -6,7 +6,6 @@ require (
 	github.com/bots-go-framework/bots-go-core v0.3.0
 	github.com/stretchr/testify v1.12.1
 	github.com/strongo/logus v0.4.1
-	github.com/technoweenie/multipartstreamer v1.0.1
 )
 
 require (
@@ -3,17 +3,14 @@
 package tgbotapi
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/strongo/logus"
-	"github.com/technoweenie/multipartstreamer"
 	"io"
 	"net/http"
 	"net/url"
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -21,10 +18,34 @@ import (
 
 // BotAPI allows you to interact with the Telegram Bot API.
 type BotAPI struct {
-	Token  string          `json:"token"`
-	Self   User            `json:"-"`
-	Client *http.Client    `json:"-"`
-	c      context.Context // TODO: Wrong? read docs on Context class
+	Token  string       `json:"token"`
+	Self   User         `json:"-"`
+	Client *http.Client `json:"-"`
+
+	// RetryPolicy is an opt-in policy for flood-control and chat-migration
+	// responses. The zero value disables automatic retries.
+	RetryPolicy RetryPolicy `json:"-"`
+
+	// RateLimiter, if set, throttles outgoing requests before they are sent,
+	// see NewTelegramRateLimiter.
+	RateLimiter RateLimiter `json:"-"`
+
+	// MaxDownloadSize, if positive, limits the size of files read with
+	// DownloadFile and OpenFile.
+	MaxDownloadSize int64 `json:"-"`
+
+	// BaseURL is the address of the Bot API server, e.g. of a self-hosted
+	// telegram-bot-api server. Empty means DefaultBaseURL.
+	BaseURL string `json:"-"`
+
+	// FileBaseURL is the address files are downloaded from. Empty means BaseURL.
+	FileBaseURL string `json:"-"`
+
+	// TestEnvironment sends requests to the Telegram test environment.
+	TestEnvironment bool `json:"-"`
+
+	c     context.Context // TODO: Wrong? read docs on Context class
+	sleep func(ctx context.Context, d time.Duration) error
 }
 
 // telegramRequestError preserves the transport error for errors.Is/errors.As
@@ -75,6 +96,18 @@ type TelegramProviderErrorDetails struct {
 	Method      string
 	ErrorCode   int
 	Description string
+
+	// RetryAfter is how long Telegram asked to wait before repeating the
+	// request after exceeding flood control, or zero.
+	RetryAfter time.Duration
+
+	// MigrateToChatID is the identifier of the supergroup the target group
+	// has been migrated to, or zero.
+	MigrateToChatID int64
+
+	// Kind is the sentinel error the response was classified as, such as
+	// ErrBotBlocked or ErrChatNotFound, or nil for unrecognised errors.
+	Kind error
 }
 
 // TelegramProviderErrorDetailsFrom extracts safe, structured diagnostics from
@@ -87,11 +120,17 @@ func TelegramProviderErrorDetailsFrom(err error) (details TelegramProviderErrorD
 	if !errors.As(err, &providerErr) {
 		return TelegramProviderErrorDetails{}, false
 	}
-	return TelegramProviderErrorDetails{
+	details = TelegramProviderErrorDetails{
 		Method:      providerErr.method,
 		ErrorCode:   providerErr.response.ErrorCode,
 		Description: providerErr.response.Description,
-	}, true
+		Kind:        classifyAPIResponse(providerErr.response),
+	}
+	if p := providerErr.response.Parameters; p != nil {
+		details.RetryAfter = time.Duration(p.RetryAfter) * time.Second
+		details.MigrateToChatID = p.MigrateToChatID
+	}
+	return details, true
 }
 
 func (e telegramProviderError) Error() string {
@@ -135,28 +174,101 @@ func NewBotAPIWithClient(token string, client *http.Client) *BotAPI {
 
 // MakeRequestFromMessageWithValues makes request from WithValues
 func (bot *BotAPI) MakeRequestFromMessageWithValues(method string, m WithValues) (resp APIResponse, err error) { //
+	return bot.MakeRequestFromMessageWithValuesContext(context.Background(), method, m)
+}
+
+// MakeRequestFromMessageWithValuesContext makes request from WithValues
+// and aborts it once ctx is cancelled or its deadline expires.
+func (bot *BotAPI) MakeRequestFromMessageWithValuesContext(ctx context.Context, method string, m WithValues) (resp APIResponse, err error) {
 	var values url.Values
 	if values, err = m.Values(); err != nil {
 		return resp, err
 	}
-	return bot.MakeRequest(method, values)
+	return bot.MakeRequestContext(ctx, method, values)
 }
 
 // MakeRequestFromChattable makes request from chattable TODO: Is duplicate of Send()?
 func (bot *BotAPI) MakeRequestFromChattable(m Sendable) (resp APIResponse, err error) { //
-	return bot.MakeRequestFromMessageWithValues(m.TelegramMethod(), m)
+	return bot.MakeRequestFromChattableContext(context.Background(), m)
+}
+
+// MakeRequestFromChattableContext makes request from chattable using ctx for the HTTP call.
+//
+// Configs with files to upload are sent in a multipart request.
+func (bot *BotAPI) MakeRequestFromChattableContext(ctx context.Context, m Sendable) (resp APIResponse, err error) {
+	if upload, ok := m.(multipartSendable); ok {
+		var r *multipartRequest
+		if r, err = upload.multipart(); err != nil {
+			return resp, err
+		}
+		return bot.sendMultipartRequest(ctx, m.TelegramMethod(), r)
+	}
+	return bot.MakeRequestFromMessageWithValuesContext(ctx, m.TelegramMethod(), m)
 }
 
-// SendRequest sends a request to a specific endpoint with our token and reads response.
+// MakeRequest sends a request to a specific endpoint with our token and reads response.
 func (bot *BotAPI) MakeRequest(telegramMethod string, params url.Values) (apiResp APIResponse, err error) {
-	endpointURL := fmt.Sprintf(APIEndpoint, bot.Token, telegramMethod)
+	return bot.MakeRequestContext(context.Background(), telegramMethod, params)
+}
+
+// MakeRequestContext sends a request to a specific endpoint with our token and reads response.
+//
+// The request is bound to ctx: cancellation or an expired deadline aborts it, and the
+// returned error unwraps to ctx.Err(). Flood-control and chat-migration responses are
+// handled according to bot.RetryPolicy.
+func (bot *BotAPI) MakeRequestContext(ctx context.Context, telegramMethod string, params url.Values) (apiResp APIResponse, err error) {
+	apiResp, err = bot.makeRequest(ctx, telegramMethod, params)
+	for retries, migrated := 0, false; err != nil; {
+		var providerErr telegramProviderError
+		if !errors.As(err, &providerErr) || providerErr.response.Parameters == nil {
+			return apiResp, err
+		}
+		switch p := providerErr.response.Parameters; {
+		case p.RetryAfter > 0:
+			wait, ok := bot.RetryPolicy.retryAfter(retries, p.RetryAfter)
+			if !ok {
+				return apiResp, err
+			}
+			retries++
+			logus.Warningf(
+				bot.c,
+				"Telegram API flood control: method=%q, retry=%d, retry_after=%v",
+				telegramMethod,
+				retries,
+				wait,
+			)
+			if sleepErr := bot.sleepContext(ctx, wait); sleepErr != nil {
+				return apiResp, errors.Join(err, sleepErr)
+			}
+		case p.MigrateToChatID != 0:
+			if migrated || !bot.RetryPolicy.FollowChatMigration || params.Get("chat_id") == "" {
+				return apiResp, err
+			}
+			migrated = true
+			params = cloneValues(params)
+			params.Set("chat_id", strconv.FormatInt(p.MigrateToChatID, 10))
+		default:
+			return apiResp, err
+		}
+		apiResp, err = bot.makeRequest(ctx, telegramMethod, params)
+	}
+	return apiResp, err
+}
+
+// makeRequest performs a single request to the Telegram API.
+func (bot *BotAPI) makeRequest(ctx context.Context, telegramMethod string, params url.Values) (apiResp APIResponse, err error) {
+	if err = bot.waitRateLimit(ctx, telegramMethod, params.Get); err != nil {
+		return APIResponse{Ok: false}, err
+	}
+
+	endpointURL := bot.methodURL(telegramMethod)
 
 	var hadDeadlineExceeded bool
 	var resp *http.Response
 
 	for i := 1; i <= 2; i++ { // TODO: Should this be in bots framework?
-		if resp, err = bot.Client.PostForm(endpointURL, params); err != nil {
-			if strings.Contains(err.Error(), "DEADLINE_EXCEEDED") {
+		if resp, err = bot.postForm(ctx, endpointURL, params); err != nil {
+			if ctx.Err() == nil && strings.Contains(err.Error(), "DEADLINE_EXCEEDED") {
 				hadDeadlineExceeded = true
 				logus.Warningf(
 					bot.c,
@@ -207,7 +319,11 @@ func (bot *BotAPI) MakeRequest(telegramMethod string, params url.Values) (apiRes
 	case http.StatusUnauthorized:
 		return apiResp, fmt.Errorf("telegram API method %q returned %s", telegramMethod, http.StatusText(resp.StatusCode))
 	case http.StatusForbidden:
-		return apiResp, &ErrAPIForbidden{}
+		forbidden := &ErrAPIForbidden{}
+		if err == nil && json.Unmarshal(apiResp.Result, &apiResp) == nil {
+			forbidden.err = telegramProviderError{method: telegramMethod, response: apiResp}
+		}
+		return apiResp, forbidden
 	}
 
 	if err != nil {
@@ -239,7 +355,7 @@ func (bot *BotAPI) MakeRequest(telegramMethod string, params url.Values) (apiRes
 		return apiResp, fmt.Errorf("telegram API method %q returned invalid JSON: %w", telegramMethod, err)
 	} else if !apiResp.Ok {
 		logRequestAndResponse()
-		if hadDeadlineExceeded && apiResp.ErrorCode == 400 && strings.Contains(apiResp.Description, "message is not modified") {
+		if hadDeadlineExceeded && classifyAPIResponse(apiResp) == ErrMessageNotModified {
 			return apiResp, nil
 		}
 		return apiResp, telegramProviderError{method: telegramMethod, response: apiResp}
@@ -248,13 +364,62 @@ func (bot *BotAPI) MakeRequest(telegramMethod string, params url.Values) (apiRes
 	return apiResp, nil
 }
 
+// waitRateLimit blocks until bot.RateLimiter allows the request to be sent.
+// param returns the request parameter of the given name.
+func (bot *BotAPI) waitRateLimit(ctx context.Context, telegramMethod string, param func(name string) string) error {
+	if bot.RateLimiter == nil {
+		return nil
+	}
+	paidBroadcast, _ := strconv.ParseBool(param("allow_paid_broadcast"))
+	return bot.RateLimiter.Wait(ctx, RateLimitRequest{
+		Method:        telegramMethod,
+		ChatID:        param("chat_id"),
+		PaidBroadcast: paidBroadcast,
+		Messages:      messageCount(telegramMethod, param),
+	})
+}
+
+// messageCount returns the number of messages a request sends: the media of
+// an album, the message_ids of a batch, or one for any other request.
+func messageCount(telegramMethod string, param func(name string) string) int {
+	var field string
+	switch telegramMethod {
+	case "sendMediaGroup":
+		field = "media"
+	case "copyMessages", "forwardMessages":
+		field = "message_ids"
+	default:
+		return 1
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(param(field)), &items); err != nil || len(items) == 0 {
+		return 1
+	}
+	return len(items)
+}
+
+// postForm posts URL-encoded params to endpointURL within ctx.
+func (bot *BotAPI) postForm(ctx context.Context, endpointURL string, params url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return bot.Client.Do(req)
+}
+
 func (bot *BotAPI) DeleteMessage(chatID string, messageID int) (apiResp APIResponse, err error) {
-	return bot.MakeRequest("deleteMessage", url.Values{"chat_id": {chatID}, "message_id": {strconv.Itoa(messageID)}})
+	return bot.DeleteMessageContext(context.Background(), chatID, messageID)
+}
+
+// DeleteMessageContext deletes a message using ctx for the HTTP call.
+func (bot *BotAPI) DeleteMessageContext(ctx context.Context, chatID string, messageID int) (apiResp APIResponse, err error) {
+	return bot.MakeRequestContext(ctx, "deleteMessage", url.Values{"chat_id": {chatID}, "message_id": {strconv.Itoa(messageID)}})
 }
 
 // makeMessageRequest makes a request to a TelegramMethod that returns a Message.
-func (bot *BotAPI) makeMessageRequest(endpoint string, params url.Values) (Message, error) {
-	resp, err := bot.MakeRequest(endpoint, params)
+func (bot *BotAPI) makeMessageRequest(ctx context.Context, endpoint string, params url.Values) (Message, error) {
+	resp, err := bot.MakeRequestContext(ctx, endpoint, params)
 	var message Message
 
 	if err != nil {
@@ -277,115 +442,43 @@ func (bot *BotAPI) makeMessageRequest(endpoint string, params url.Values) (Messa
 //
 // Requires the parameter to hold the file not be in the params.
 // File should be a string to a file path, a FileBytes struct,
-// or a FileReader struct.
-//
-// Note that if your FileReader has a size set to -1, it will read
-// the file into memory to calculate a size.
+// a FileReader struct or an InputFile. The file is streamed, it is
+// never read into memory as a whole.
 func (bot *BotAPI) UploadFile(endpoint string, params map[string]string, fieldname string, file interface{}) (apiResp APIResponse, err error) {
-	ms := multipartstreamer.New()
-	if err = ms.WriteFields(params); err != nil {
-		return
-	}
-
-	switch f := file.(type) {
-	case string:
-		var fileHandle *os.File
-		if fileHandle, err = os.Open(f); err != nil {
-			return
-		}
-		defer func() {
-			_ = fileHandle.Close()
-		}()
-
-		var fi os.FileInfo
-		if fi, err = os.Stat(f); err != nil {
-			return
-		}
-
-		if err = ms.WriteReader(fieldname, fileHandle.Name(), fi.Size(), fileHandle); err != nil {
-			return
-		}
-	case FileBytes:
-		buf := bytes.NewBuffer(f.Bytes)
-		if err = ms.WriteReader(fieldname, f.Name, int64(len(f.Bytes)), buf); err != nil {
-			return
-		}
-	case FileReader:
-		if f.Size != -1 {
-			if err = ms.WriteReader(fieldname, f.Name, f.Size, f.Reader); err != nil {
-				return
-			}
-			break
-		}
-
-		var data []byte
-		if data, err = io.ReadAll(f.Reader); err != nil {
-			return
-		}
-
-		buf := bytes.NewBuffer(data)
-
-		if err = ms.WriteReader(fieldname, f.Name, int64(len(data)), buf); err != nil {
-			return
-		}
-	default:
-		err = ErrBadFileType
-		return
-	}
-
-	method := fmt.Sprintf(APIEndpoint, bot.Token, endpoint)
+	return bot.UploadFileContext(context.Background(), endpoint, params, fieldname, file)
+}
 
-	var req *http.Request
-	if req, err = http.NewRequest("POST", method, nil); err != nil {
+// UploadFileContext makes a request to the API with a file, aborting the
+// upload once ctx is cancelled or its deadline expires.
+func (bot *BotAPI) UploadFileContext(ctx context.Context, endpoint string, params map[string]string, fieldname string, file interface{}) (apiResp APIResponse, err error) {
+	var f InputFile
+	if f, err = inputFileFrom(file); err != nil {
 		return
 	}
-
-	ms.SetupRequest(req)
-
-	var res *http.Response
-	if res, err = bot.Client.Do(req); err != nil {
-		return apiResp, telegramRequestError{method: endpoint, err: err}
-	}
-	defer func() {
-		_ = res.Body.Close()
-	}()
-
-	var body []byte
-	if body, err = io.ReadAll(res.Body); err != nil {
-		return apiResp, telegramResponseIOError{method: endpoint, err: err}
-	}
-
-	if bot.c != nil {
-		logus.Debugf(
-			bot.c,
-			"Telegram API upload response: method=%q, status=%d, body_bytes=%d",
-			endpoint,
-			res.StatusCode,
-			len(body),
-		)
-	}
-
-	if err = json.Unmarshal(body, &apiResp); err != nil {
+	r := newMultipartRequest(params)
+	if err = r.addFile(fieldname, f); err != nil {
 		return
 	}
-
-	if !apiResp.Ok {
-		return apiResp, telegramProviderError{method: endpoint, response: apiResp}
-	}
-	return
+	return bot.sendMultipartRequest(ctx, endpoint, r)
 }
 
 // GetFileDirectURL returns direct URL to file
 //
-// It requires the FileID.
+// It requires the FileID. The URL contains the bot token, so it must not be
+// logged or shared; use DownloadFile or OpenFile to get the file instead.
 func (bot *BotAPI) GetFileDirectURL(fileID string) (string, error) {
-	file, err := bot.GetFile(FileConfig{fileID})
+	return bot.GetFileDirectURLContext(context.Background(), fileID)
+}
+
+// GetFileDirectURLContext returns direct URL to file using ctx for the getFile call.
+func (bot *BotAPI) GetFileDirectURLContext(ctx context.Context, fileID string) (string, error) {
+	file, err := bot.GetFileContext(ctx, FileConfig{fileID})
 
 	if err != nil {
 		return "", err
 	}
 
-	return file.Link(bot.Token), nil
+	return bot.FileURL(file)
 }
 
 // GetMe fetches the currently authenticated bot.
@@ -394,9 +487,14 @@ func (bot *BotAPI) GetFileDirectURL(fileID string) (string, error) {
 // and so you may get this data from BotAPI.Self without the need for
 // another request.
 func (bot *BotAPI) GetMe() (User, error) {
+	return bot.GetMeContext(context.Background())
+}
+
+// GetMeContext fetches the currently authenticated bot using ctx for the HTTP call.
+func (bot *BotAPI) GetMeContext(ctx context.Context) (User, error) {
 	var user User
 
-	resp, err := bot.MakeRequest("getMe", nil)
+	resp, err := bot.MakeRequestContext(ctx, "getMe", nil)
 	if err != nil {
 		return user, err
 	}
@@ -411,9 +509,14 @@ func (bot *BotAPI) GetMe() (User, error) {
 }
 
 func (bot *BotAPI) GetChat(chatID string) (Chat, error) {
+	return bot.GetChatContext(context.Background(), chatID)
+}
+
+// GetChatContext returns up-to-date information about the chat using ctx for the HTTP call.
+func (bot *BotAPI) GetChatContext(ctx context.Context, chatID string) (Chat, error) {
 	var chat Chat
 
-	resp, err := bot.MakeRequest("getChat", url.Values{"chat_id": []string{chatID}})
+	resp, err := bot.MakeRequestContext(ctx, "getChat", url.Values{"chat_id": []string{chatID}})
 	if err != nil {
 		return chat, err
 	}
@@ -438,11 +541,23 @@ func (bot *BotAPI) IsMessageToMe(message Message) bool {
 //
 // It requires the Sendable to send.
 func (bot *BotAPI) Send(c Sendable) (Message, error) {
+	return bot.SendContext(context.Background(), c)
+}
+
+// SendContext will send a Sendable item to Telegram, aborting the request
+// once ctx is cancelled or its deadline expires.
+//
+// A media group is sent as several messages, use SendMediaGroupContext for it.
+func (bot *BotAPI) SendContext(ctx context.Context, c Sendable) (Message, error) {
 	switch t := c.(type) {
+	case MediaGroupConfig, *MediaGroupConfig:
+		return Message{}, errors.New("a media group is sent as several messages, use SendMediaGroup")
+	case multipartSendable:
+		return bot.sendMultipart(ctx, t)
 	case Fileable:
-		return bot.sendFile(t)
+		return bot.sendFile(ctx, t)
 	default:
-		return bot.sendChattable(t)
+		return bot.sendChattable(ctx, t)
 	}
 }
 
@@ -461,14 +576,14 @@ func (bot *BotAPI) debugLog(operation string, v url.Values, message interface{})
 }
 
 // sendExisting will send a Message with an existing file to Telegram.
-func (bot *BotAPI) sendExisting(method string, config Fileable) (Message, error) {
+func (bot *BotAPI) sendExisting(ctx context.Context, method string, config Fileable) (Message, error) {
 	v, err := config.Values()
 
 	if err != nil {
 		return Message{}, err
 	}
 
-	message, err := bot.makeMessageRequest(method, v)
+	message, err := bot.makeMessageRequest(ctx, method, v)
 	if err != nil {
 		return Message{}, err
 	}
@@ -477,7 +592,7 @@ func (bot *BotAPI) sendExisting(method string, config Fileable) (Message, error)
 }
 
 // uploadAndSend will send a Message with a new file to Telegram.
-func (bot *BotAPI) uploadAndSend(method string, config Fileable) (Message, error) {
+func (bot *BotAPI) uploadAndSend(ctx context.Context, method string, config Fileable) (Message, error) {
 	var message Message
 
 	params, err := config.params()
@@ -485,9 +600,19 @@ func (bot *BotAPI) uploadAndSend(method string, config Fileable) (Message, error
 		return message, err
 	}
 
-	file := config.getFile()
+	r := newMultipartRequest(params)
+	if err = config.addFiles(r, config.name()); err != nil {
+		return message, err
+	}
+
+	return bot.sendMultipartMessage(ctx, method, r)
+}
+
+// sendMultipartMessage sends r as a multipart request and decodes the Message.
+func (bot *BotAPI) sendMultipartMessage(ctx context.Context, method string, r *multipartRequest) (Message, error) {
+	var message Message
 
-	resp, err := bot.UploadFile(method, params, config.name(), file)
+	resp, err := bot.sendMultipartRequest(ctx, method, r)
 	if err != nil {
 		return message, err
 	}
@@ -502,23 +627,90 @@ func (bot *BotAPI) uploadAndSend(method string, config Fileable) (Message, error
 }
 
 // sendFile determines if the file is using an existing file or uploading
-// a new file, then sends it as needed.
-func (bot *BotAPI) sendFile(config Fileable) (Message, error) {
-	if config.useExistingFile() {
-		return bot.sendExisting(config.TelegramMethod(), config)
+// a new file, then sends it as needed. An existing file is sent in a
+// multipart request when its thumbnail or cover has to be uploaded.
+func (bot *BotAPI) sendFile(ctx context.Context, config Fileable) (Message, error) {
+	if !config.useExistingFile() {
+		return bot.uploadAndSend(ctx, config.TelegramMethod(), config)
+	}
+
+	v, err := config.Values()
+	if err != nil {
+		return Message{}, err
+	}
+	r := newMultipartRequest(paramsFromValues(v))
+	if err = config.attachFiles(r); err != nil {
+		return Message{}, err
+	}
+	if len(r.parts) == 0 {
+		return bot.sendExisting(ctx, config.TelegramMethod(), config)
 	}
 
-	return bot.uploadAndSend(config.TelegramMethod(), config)
+	return bot.sendMultipartMessage(ctx, config.TelegramMethod(), r)
+}
+
+// sendMultipart sends a config that may carry files to upload.
+func (bot *BotAPI) sendMultipart(ctx context.Context, config multipartSendable) (Message, error) {
+	var message Message
+
+	r, err := config.multipart()
+	if err != nil {
+		return message, err
+	}
+
+	method := config.TelegramMethod()
+	if len(r.parts) == 0 {
+		return bot.makeMessageRequest(ctx, method, r.values())
+	}
+
+	resp, err := bot.uploadParts(ctx, method, r.params, r.parts)
+	if err != nil {
+		return message, err
+	}
+
+	if err = json.Unmarshal(resp.Result, &message); err != nil {
+		return message, fmt.Errorf("failed to decode Telegram API response for method %q: %w", method, err)
+	}
+
+	bot.debugLog(method, nil, message)
+
+	return message, nil
 }
 
 // sendChattable sends a Sendable.
-func (bot *BotAPI) sendChattable(config Sendable) (Message, error) {
+func (bot *BotAPI) sendChattable(ctx context.Context, config Sendable) (Message, error) {
 	v, err := config.Values()
 	if err != nil {
 		return Message{}, err
 	}
 
-	return bot.makeMessageRequest(config.TelegramMethod(), v)
+	return bot.makeMessageRequest(ctx, config.TelegramMethod(), v)
+}
+
+// SendMediaGroup sends a group of photos, videos, documents or audio files as
+// an album, uploading local files in a single request.
+func (bot *BotAPI) SendMediaGroup(config MediaGroupConfig) ([]Message, error) {
+	return bot.SendMediaGroupContext(context.Background(), config)
+}
+
+// SendMediaGroupContext is SendMediaGroup using ctx for the HTTP call.
+func (bot *BotAPI) SendMediaGroupContext(ctx context.Context, config MediaGroupConfig) ([]Message, error) {
+	r, err := config.multipart()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := bot.sendMultipartRequest(ctx, config.TelegramMethod(), r)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []Message
+	if err = json.Unmarshal(resp.Result, &messages); err != nil {
+		return nil, fmt.Errorf("failed to decode Telegram API response for method %q: %w", config.TelegramMethod(), err)
+	}
+	bot.debugLog(config.TelegramMethod(), nil, messages)
+	return messages, nil
 }
 
 // GetUserProfilePhotos gets a user's profile photos.
@@ -526,6 +718,11 @@ func (bot *BotAPI) sendChattable(config Sendable) (Message, error) {
 // It requires UserID.
 // Offset and Limit are optional.
 func (bot *BotAPI) GetUserProfilePhotos(config UserProfilePhotosConfig) (UserProfilePhotos, error) {
+	return bot.GetUserProfilePhotosContext(context.Background(), config)
+}
+
+// GetUserProfilePhotosContext gets a user's profile photos using ctx for the HTTP call.
+func (bot *BotAPI) GetUserProfilePhotosContext(ctx context.Context, config UserProfilePhotosConfig) (UserProfilePhotos, error) {
 	var profilePhotos UserProfilePhotos
 
 	v := url.Values{}
@@ -537,7 +734,7 @@ func (bot *BotAPI) GetUserProfilePhotos(config UserProfilePhotosConfig) (UserPro
 		v.Add("limit", strconv.Itoa(config.Limit))
 	}
 
-	resp, err := bot.MakeRequest("getUserProfilePhotos", v)
+	resp, err := bot.MakeRequestContext(ctx, "getUserProfilePhotos", v)
 	if err != nil {
 		return profilePhotos, err
 	}
@@ -555,12 +752,18 @@ func (bot *BotAPI) GetUserProfilePhotos(config UserProfilePhotosConfig) (UserPro
 //
 // Requires FileID.
 func (bot *BotAPI) GetFile(config FileConfig) (File, error) {
+	return bot.GetFileContext(context.Background(), config)
+}
+
+// GetFileContext returns a File which can download a file from Telegram
+// using ctx for the HTTP call.
+func (bot *BotAPI) GetFileContext(ctx context.Context, config FileConfig) (File, error) {
 	var file File
 
 	v := url.Values{}
 	v.Add("file_id", config.FileID)
 
-	resp, err := bot.MakeRequest("getFile", v)
+	resp, err := bot.MakeRequestContext(ctx, "getFile", v)
 	if err != nil {
 		return file, err
 	}
@@ -582,6 +785,14 @@ func (bot *BotAPI) GetFile(config FileConfig) (File, error) {
 // Set Timeout to a large number to reduce requests so you can get updates
 // instantly instead of having to wait between requests.
 func (bot *BotAPI) GetUpdates(config *UpdateConfig) ([]Update, error) {
+	return bot.GetUpdatesContext(context.Background(), config)
+}
+
+// GetUpdatesContext fetches updates using ctx for the HTTP call.
+//
+// Long polling requests (config.Timeout > 0) return early with ctx.Err()
+// when ctx is cancelled.
+func (bot *BotAPI) GetUpdatesContext(ctx context.Context, config *UpdateConfig) ([]Update, error) {
 	var updates []Update
 
 	v := url.Values{}
@@ -594,8 +805,15 @@ func (bot *BotAPI) GetUpdates(config *UpdateConfig) ([]Update, error) {
 	if config.Timeout > 0 {
 		v.Add("timeout", strconv.Itoa(config.Timeout))
 	}
+	if len(config.AllowedUpdates) > 0 {
+		data, err := encodeToJson(config.AllowedUpdates)
+		if err != nil {
+			return updates, err
+		}
+		v.Add("allowed_updates", string(data))
+	}
 
-	resp, err := bot.MakeRequest("getUpdates", v)
+	resp, err := bot.MakeRequestContext(ctx, "getUpdates", v)
 	if err != nil {
 		return updates, err
 	}
@@ -610,8 +828,17 @@ func (bot *BotAPI) GetUpdates(config *UpdateConfig) ([]Update, error) {
 }
 
 // RemoveWebhook unsets the webhook.
+//
+// Deprecated: use DeleteWebhook, which can also drop pending updates.
 func (bot *BotAPI) RemoveWebhook() (APIResponse, error) {
-	return bot.MakeRequest("removeWebhook", url.Values{})
+	return bot.RemoveWebhookContext(context.Background())
+}
+
+// RemoveWebhookContext unsets the webhook using ctx for the HTTP call.
+//
+// Deprecated: use DeleteWebhookContext, which can also drop pending updates.
+func (bot *BotAPI) RemoveWebhookContext(ctx context.Context) (APIResponse, error) {
+	return bot.DeleteWebhookContext(ctx, DeleteWebhookConfig{})
 }
 
 // SetWebhook sets a webhook.
@@ -620,81 +847,35 @@ func (bot *BotAPI) RemoveWebhook() (APIResponse, error) {
 //
 // If you do not have a legitimate TLS certificate, you need to include your self-signed certificate with the config.
 func (bot *BotAPI) SetWebhook(config WebhookConfig) (APIResponse, error) {
-	if config.Certificate == nil {
-		params, err := config.Values()
-		if err != nil {
-			return APIResponse{}, err
-		}
-		return bot.MakeRequest("setWebhook", params)
-	} else {
-		var apiResp APIResponse
-		resp, err := bot.UploadFile("setWebhook", map[string]string{"url": config.URL.String()}, "certificate", config.Certificate)
-		if err != nil {
-			return apiResp, err
-		}
-
-		if err = json.Unmarshal(resp.Result, &apiResp); err != nil {
-			return apiResp, err
-		}
-
-		if bot.c != nil {
-			logus.Debugf(
-				bot.c,
-				"Telegram API operation: method=%q, ok=%t, error_code=%d, result_bytes=%d",
-				"setWebhook",
-				apiResp.Ok,
-				apiResp.ErrorCode,
-				len(apiResp.Result),
-			)
-		}
+	return bot.SetWebhookContext(context.Background(), config)
+}
 
-		return apiResp, nil
+// SetWebhookContext sets a webhook using ctx for the HTTP call.
+//
+// All the parameters of config are sent, in a multipart request if it has a Certificate.
+func (bot *BotAPI) SetWebhookContext(ctx context.Context, config WebhookConfig) (APIResponse, error) {
+	r, err := config.multipart()
+	if err != nil {
+		return APIResponse{}, err
 	}
+	return bot.sendMultipartRequest(ctx, "setWebhook", r)
 }
 
 // GetUpdatesChan starts and returns a channel for getting updates.
+//
+// Polling never stops; use NewUpdater to stop it with a context and to
+// observe errors.
 func (bot *BotAPI) GetUpdatesChan(config *UpdateConfig) (<-chan Update, error) {
-	updatesChan := make(chan Update, 100)
-
-	go func() {
-		for {
-			updates, err := bot.GetUpdates(config)
-			if err != nil {
-				//logus.Println(err)
-				//logus.Println("Failed to get updates, retrying in 3 seconds...")
-				time.Sleep(time.Second * 3)
-
-				continue
-			}
-
-			for _, update := range updates {
-				if update.UpdateID >= config.Offset {
-					config.Offset = update.UpdateID + 1
-					updatesChan <- update
-				}
-			}
-		}
-	}()
-
-	return updatesChan, nil
+	return NewUpdater(bot, *config).Start(context.Background()), nil
 }
 
-// ListenForWebhook registers a http handler for a webhook.
+// ListenForWebhook registers a http handler for a webhook on http.DefaultServeMux.
+//
+// Use NewWebhookHandler or NewWebhookChannelHandler to mount a handler on your
+// own mux and to verify the secret token.
 func (bot *BotAPI) ListenForWebhook(pattern string) <-chan Update {
-	updatesChan := make(chan Update, 100)
-
-	http.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
-		body, _ := io.ReadAll(r.Body)
-
-		var update Update
-		if err := json.Unmarshal(body, &update); err != nil {
-			logus.Errorf(context.Background(), fmt.Errorf("failed to unmarshal update JSON: %w", err).Error())
-			return
-		}
-
-		updatesChan <- update
-	})
-
+	handler, updatesChan := NewWebhookChannelHandler("", 100)
+	http.Handle(pattern, handler)
 	return updatesChan
 }
 
@@ -702,6 +883,11 @@ func (bot *BotAPI) ListenForWebhook(pattern string) <-chan Update {
 //
 // Note that you must respond to an inline query within 30 seconds.
 func (bot *BotAPI) AnswerInlineQuery(config InlineConfig) (APIResponse, error) {
+	return bot.AnswerInlineQueryContext(context.Background(), config)
+}
+
+// AnswerInlineQueryContext sends a response to an inline query using ctx for the HTTP call.
+func (bot *BotAPI) AnswerInlineQueryContext(ctx context.Context, config InlineConfig) (APIResponse, error) {
 	v := url.Values{}
 
 	v.Add("inline_query_id", config.InlineQueryID)
@@ -730,54 +916,66 @@ func (bot *BotAPI) AnswerInlineQuery(config InlineConfig) (APIResponse, error) {
 
 	bot.debugLog("answerInlineQuery", v, nil)
 
-	return bot.MakeRequest("answerInlineQuery", v)
+	return bot.MakeRequestContext(ctx, "answerInlineQuery", v)
 }
 
 // KickChatMember kicks a user from a chat. Note that this only will work
 // in supergroups, and requires the bot to be an admin. Also note they
 // will be unable to rejoin until they are unbanned.
+//
+// Deprecated: use BanChatMember, kickChatMember is a deprecated alias of banChatMember.
 func (bot *BotAPI) KickChatMember(config ChatMemberConfig) (APIResponse, error) {
-	v := url.Values{}
+	return bot.KickChatMemberContext(context.Background(), config)
+}
 
-	if config.SuperGroupUsername == "" {
-		v.Add("chat_id", strconv.FormatInt(config.ChatID, 10))
-	} else {
-		v.Add("chat_id", config.SuperGroupUsername)
-	}
-	v.Add("user_id", strconv.Itoa(config.UserID))
+// KickChatMemberContext kicks a user from a chat using ctx for the HTTP call.
+//
+// Deprecated: use BanChatMemberContext.
+func (bot *BotAPI) KickChatMemberContext(ctx context.Context, config ChatMemberConfig) (APIResponse, error) {
+	v := config.values()
 
 	bot.debugLog("kickChatMember", v, nil)
 
-	return bot.MakeRequest("kickChatMember", v)
+	return bot.MakeRequestContext(ctx, "kickChatMember", v)
 }
 
 // UnbanChatMember unbans a user from a chat. Note that this only will work
 // in supergroups, and requires the bot to be an admin.
 func (bot *BotAPI) UnbanChatMember(config ChatMemberConfig) (APIResponse, error) {
-	v := url.Values{}
+	return bot.UnbanChatMemberContext(context.Background(), config)
+}
 
-	if config.SuperGroupUsername == "" {
-		v.Add("chat_id", strconv.FormatInt(config.ChatID, 10))
-	} else {
-		v.Add("chat_id", config.SuperGroupUsername)
-	}
-	v.Add("user_id", strconv.Itoa(config.UserID))
+// UnbanChatMemberContext unbans a user from a chat using ctx for the HTTP call.
+func (bot *BotAPI) UnbanChatMemberContext(ctx context.Context, config ChatMemberConfig) (APIResponse, error) {
+	v := config.values()
 
 	bot.debugLog("unbanChatMember", v, nil)
 
-	return bot.MakeRequest("unbanChatMember", v)
+	return bot.MakeRequestContext(ctx, "unbanChatMember", v)
 }
 
 func (bot *BotAPI) SetDescription(config SetMyDescription) (APIResponse, error) {
-	return bot.MakeRequestFromChattable(config)
+	return bot.SetDescriptionContext(context.Background(), config)
+}
+
+func (bot *BotAPI) SetDescriptionContext(ctx context.Context, config SetMyDescription) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
 }
 
 func (bot *BotAPI) SetShortDescription(config SetMyShortDescription) (APIResponse, error) {
-	return bot.MakeRequestFromChattable(config)
+	return bot.SetShortDescriptionContext(context.Background(), config)
+}
+
+func (bot *BotAPI) SetShortDescriptionContext(ctx context.Context, config SetMyShortDescription) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
 }
 
 func (bot *BotAPI) SetCommands(config SetMyCommandsConfig) (APIResponse, error) {
-	return bot.MakeRequestFromChattable(config)
+	return bot.SetCommandsContext(context.Background(), config)
+}
+
+func (bot *BotAPI) SetCommandsContext(ctx context.Context, config SetMyCommandsConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
 }
 
 func (bot *BotAPI) GetCommands(ctx context.Context, config GetMyCommandsConfig) (commands []TelegramBotCommand, err error) {
@@ -789,10 +987,15 @@ func (bot *BotAPI) GetCommands(ctx context.Context, config GetMyCommandsConfig)
 //
 // https://core.telegram.org/bots/api#getmanagedbottoken
 func (bot *BotAPI) GetManagedBotToken(userID int64) (token string, err error) {
+	return bot.GetManagedBotTokenContext(context.Background(), userID)
+}
+
+// GetManagedBotTokenContext is GetManagedBotToken using ctx for the HTTP call.
+func (bot *BotAPI) GetManagedBotTokenContext(ctx context.Context, userID int64) (token string, err error) {
 	v := url.Values{}
 	v.Add("user_id", strconv.FormatInt(userID, 10))
 
-	resp, err := bot.MakeRequest("getManagedBotToken", v)
+	resp, err := bot.MakeRequestContext(ctx, "getManagedBotToken", v)
 	if err != nil {
 		return "", err
 	}
@@ -810,10 +1013,15 @@ func (bot *BotAPI) GetManagedBotToken(userID int64) (token string, err error) {
 //
 // https://core.telegram.org/bots/api#replacemanagedbottoken
 func (bot *BotAPI) ReplaceManagedBotToken(userID int64) (token string, err error) {
+	return bot.ReplaceManagedBotTokenContext(context.Background(), userID)
+}
+
+// ReplaceManagedBotTokenContext is ReplaceManagedBotToken using ctx for the HTTP call.
+func (bot *BotAPI) ReplaceManagedBotTokenContext(ctx context.Context, userID int64) (token string, err error) {
 	v := url.Values{}
 	v.Add("user_id", strconv.FormatInt(userID, 10))
 
-	resp, err := bot.MakeRequest("replaceManagedBotToken", v)
+	resp, err := bot.MakeRequestContext(ctx, "replaceManagedBotToken", v)
 	if err != nil {
 		return "", err
 	}
@@ -833,6 +1041,11 @@ func (bot *BotAPI) ReplaceManagedBotToken(userID int64) (token string, err error
 //
 // https://core.telegram.org/bots/api#savepreparedkeyboardbutton
 func (bot *BotAPI) SavePreparedKeyboardButton(userID int64, button KeyboardButton) (prepared PreparedKeyboardButton, err error) {
+	return bot.SavePreparedKeyboardButtonContext(context.Background(), userID, button)
+}
+
+// SavePreparedKeyboardButtonContext is SavePreparedKeyboardButton using ctx for the HTTP call.
+func (bot *BotAPI) SavePreparedKeyboardButtonContext(ctx context.Context, userID int64, button KeyboardButton) (prepared PreparedKeyboardButton, err error) {
 	v := url.Values{}
 	v.Add("user_id", strconv.FormatInt(userID, 10))
 
@@ -842,7 +1055,7 @@ func (bot *BotAPI) SavePreparedKeyboardButton(userID int64, button KeyboardButto
 	}
 	v.Add("button", string(data))
 
-	resp, err := bot.MakeRequest("savePreparedKeyboardButton", v)
+	resp, err := bot.MakeRequestContext(ctx, "savePreparedKeyboardButton", v)
 	if err != nil {
 		return prepared, err
 	}
@@ -860,10 +1073,15 @@ func (bot *BotAPI) SavePreparedKeyboardButton(userID int64, button KeyboardButto
 //
 // https://core.telegram.org/bots/api#getmanagedbotaccesssettings
 func (bot *BotAPI) GetManagedBotAccessSettings(userID int64) (settings BotAccessSettings, err error) {
+	return bot.GetManagedBotAccessSettingsContext(context.Background(), userID)
+}
+
+// GetManagedBotAccessSettingsContext is GetManagedBotAccessSettings using ctx for the HTTP call.
+func (bot *BotAPI) GetManagedBotAccessSettingsContext(ctx context.Context, userID int64) (settings BotAccessSettings, err error) {
 	v := url.Values{}
 	v.Add("user_id", strconv.FormatInt(userID, 10))
 
-	resp, err := bot.MakeRequest("getManagedBotAccessSettings", v)
+	resp, err := bot.MakeRequestContext(ctx, "getManagedBotAccessSettings", v)
 	if err != nil {
 		return settings, err
 	}
@@ -881,6 +1099,11 @@ func (bot *BotAPI) GetManagedBotAccessSettings(userID int64) (settings BotAccess
 //
 // https://core.telegram.org/bots/api#setmanagedbotaccesssettings
 func (bot *BotAPI) SetManagedBotAccessSettings(userID int64, isAccessRestricted bool, addedUserIDs []int64) (APIResponse, error) {
+	return bot.SetManagedBotAccessSettingsContext(context.Background(), userID, isAccessRestricted, addedUserIDs)
+}
+
+// SetManagedBotAccessSettingsContext is SetManagedBotAccessSettings using ctx for the HTTP call.
+func (bot *BotAPI) SetManagedBotAccessSettingsContext(ctx context.Context, userID int64, isAccessRestricted bool, addedUserIDs []int64) (APIResponse, error) {
 	if userID == 0 {
 		return APIResponse{}, errors.New("user_id is required")
 	}
@@ -900,7 +1123,7 @@ func (bot *BotAPI) SetManagedBotAccessSettings(userID int64, isAccessRestricted
 
 	bot.debugLog("setManagedBotAccessSettings", v, nil)
 
-	return bot.MakeRequest("setManagedBotAccessSettings", v)
+	return bot.MakeRequestContext(ctx, "setManagedBotAccessSettings", v)
 }
 
 // GetUserPersonalChatMessages returns recent messages posted to a user's personal chat, as shown on
@@ -908,6 +1131,11 @@ func (bot *BotAPI) SetManagedBotAccessSettings(userID int64, isAccessRestricted
 //
 // https://core.telegram.org/bots/api#getuserpersonalchatmessages
 func (bot *BotAPI) GetUserPersonalChatMessages(userID int64, requestedLimit ...int) (messages []Message, err error) {
+	return bot.GetUserPersonalChatMessagesContext(context.Background(), userID, requestedLimit...)
+}
+
+// GetUserPersonalChatMessagesContext is GetUserPersonalChatMessages using ctx for the HTTP call.
+func (bot *BotAPI) GetUserPersonalChatMessagesContext(ctx context.Context, userID int64, requestedLimit ...int) (messages []Message, err error) {
 	limit := 20
 	if len(requestedLimit) > 1 {
 		return nil, errors.New("only one limit may be specified")
@@ -925,7 +1153,7 @@ func (bot *BotAPI) GetUserPersonalChatMessages(userID int64, requestedLimit ...i
 	v.Add("user_id", strconv.FormatInt(userID, 10))
 	v.Add("limit", strconv.Itoa(limit))
 
-	resp, err := bot.MakeRequest("getUserPersonalChatMessages", v)
+	resp, err := bot.MakeRequestContext(ctx, "getUserPersonalChatMessages", v)
 	if err != nil {
 		return nil, err
 	}
@@ -944,6 +1172,11 @@ func (bot *BotAPI) GetUserPersonalChatMessages(userID int64, requestedLimit ...i
 //
 // https://core.telegram.org/bots/api#answerguestquery
 func (bot *BotAPI) AnswerGuestQuery(guestQueryID string, result InlineQueryResult) (sent SentGuestMessage, err error) {
+	return bot.AnswerGuestQueryContext(context.Background(), guestQueryID, result)
+}
+
+// AnswerGuestQueryContext is AnswerGuestQuery using ctx for the HTTP call.
+func (bot *BotAPI) AnswerGuestQueryContext(ctx context.Context, guestQueryID string, result InlineQueryResult) (sent SentGuestMessage, err error) {
 	if guestQueryID == "" {
 		return sent, errors.New("guest_query_id is required")
 	}
@@ -961,7 +1194,7 @@ func (bot *BotAPI) AnswerGuestQuery(guestQueryID string, result InlineQueryResul
 	}
 	v.Add("result", string(resultJSON))
 
-	resp, err := bot.MakeRequest("answerGuestQuery", v)
+	resp, err := bot.MakeRequestContext(ctx, "answerGuestQuery", v)
 	if err != nil {
 		return sent, err
 	}
@@ -980,6 +1213,11 @@ func (bot *BotAPI) AnswerGuestQuery(guestQueryID string, result InlineQueryResul
 //
 // https://core.telegram.org/bots/api#getchatadministrators
 func (bot *BotAPI) GetChatAdministrators(chatID string, includeBots ...bool) (members []ChatMember, err error) {
+	return bot.GetChatAdministratorsContext(context.Background(), chatID, includeBots...)
+}
+
+// GetChatAdministratorsContext is GetChatAdministrators using ctx for the HTTP call.
+func (bot *BotAPI) GetChatAdministratorsContext(ctx context.Context, chatID string, includeBots ...bool) (members []ChatMember, err error) {
 	if chatID == "" {
 		return nil, errors.New("chat_id is required")
 	}
@@ -992,11 +1230,11 @@ func (bot *BotAPI) GetChatAdministrators(chatID string, includeBots ...bool) (me
 	if returnBots {
 		v.Add("return_bots", "true")
 	}
-	resp, err := bot.MakeRequest("getChatAdministrators", v)
+	resp, err := bot.MakeRequestContext(ctx, "getChatAdministrators", v)
 	if err != nil {
 		return nil, err
 	}
-	if err = json.Unmarshal(resp.Result, &members); err != nil {
+	if members, err = unmarshalChatMembers(resp.Result); err != nil {
 		return nil, err
 	}
 	bot.debugLog("getChatAdministrators", v, members)
@@ -1008,13 +1246,18 @@ func (bot *BotAPI) GetChatAdministrators(chatID string, includeBots ...bool) (me
 //
 // https://core.telegram.org/bots/api#deleteallmessagereactions
 func (bot *BotAPI) DeleteAllMessageReactions(chatID int64, messageID int) (APIResponse, error) {
+	return bot.DeleteAllMessageReactionsContext(context.Background(), chatID, messageID)
+}
+
+// DeleteAllMessageReactionsContext is DeleteAllMessageReactions using ctx for the HTTP call.
+func (bot *BotAPI) DeleteAllMessageReactionsContext(ctx context.Context, chatID int64, messageID int) (APIResponse, error) {
 	v := url.Values{}
 	v.Add("chat_id", strconv.FormatInt(chatID, 10))
 	v.Add("message_id", strconv.Itoa(messageID))
 
 	bot.debugLog("deleteAllMessageReactions", v, nil)
 
-	return bot.MakeRequest("deleteAllMessageReactions", v)
+	return bot.MakeRequestContext(ctx, "deleteAllMessageReactions", v)
 }
 
 // DeleteMessageReaction removes a specific user's reaction from a message. Requires the
@@ -1022,6 +1265,11 @@ func (bot *BotAPI) DeleteAllMessageReactions(chatID int64, messageID int) (APIRe
 //
 // https://core.telegram.org/bots/api#deletemessagereaction
 func (bot *BotAPI) DeleteMessageReaction(chatID int64, messageID int, userID int64) (APIResponse, error) {
+	return bot.DeleteMessageReactionContext(context.Background(), chatID, messageID, userID)
+}
+
+// DeleteMessageReactionContext is DeleteMessageReaction using ctx for the HTTP call.
+func (bot *BotAPI) DeleteMessageReactionContext(ctx context.Context, chatID int64, messageID int, userID int64) (APIResponse, error) {
 	v := url.Values{}
 	v.Add("chat_id", strconv.FormatInt(chatID, 10))
 	v.Add("message_id", strconv.Itoa(messageID))
@@ -1029,13 +1277,18 @@ func (bot *BotAPI) DeleteMessageReaction(chatID int64, messageID int, userID int
 
 	bot.debugLog("deleteMessageReaction", v, nil)
 
-	return bot.MakeRequest("deleteMessageReaction", v)
+	return bot.MakeRequestContext(ctx, "deleteMessageReaction", v)
 }
 
 // AnswerChatJoinRequestQuery processes a received chat join request query. Bot API 10.1+
 //
 // https://core.telegram.org/bots/api#answerchatjoinrequestquery
 func (bot *BotAPI) AnswerChatJoinRequestQuery(chatJoinRequestQueryID string, result ChatJoinRequestQueryResult) (APIResponse, error) {
+	return bot.AnswerChatJoinRequestQueryContext(context.Background(), chatJoinRequestQueryID, result)
+}
+
+// AnswerChatJoinRequestQueryContext is AnswerChatJoinRequestQuery using ctx for the HTTP call.
+func (bot *BotAPI) AnswerChatJoinRequestQueryContext(ctx context.Context, chatJoinRequestQueryID string, result ChatJoinRequestQueryResult) (APIResponse, error) {
 	if chatJoinRequestQueryID == "" {
 		return APIResponse{}, errors.New("chat_join_request_query_id is required")
 	}
@@ -1050,7 +1303,7 @@ func (bot *BotAPI) AnswerChatJoinRequestQuery(chatJoinRequestQueryID string, res
 
 	bot.debugLog("answerChatJoinRequestQuery", v, nil)
 
-	return bot.MakeRequest("answerChatJoinRequestQuery", v)
+	return bot.MakeRequestContext(ctx, "answerChatJoinRequestQuery", v)
 }
 
 // SendChatJoinRequestWebApp processes a received chat join request query by showing a Mini App to the
@@ -1059,6 +1312,11 @@ func (bot *BotAPI) AnswerChatJoinRequestQuery(chatJoinRequestQueryID string, res
 //
 // https://core.telegram.org/bots/api#sendchatjoinrequestwebapp
 func (bot *BotAPI) SendChatJoinRequestWebApp(chatJoinRequestQueryID, webAppURL string) (APIResponse, error) {
+	return bot.SendChatJoinRequestWebAppContext(context.Background(), chatJoinRequestQueryID, webAppURL)
+}
+
+// SendChatJoinRequestWebAppContext is SendChatJoinRequestWebApp using ctx for the HTTP call.
+func (bot *BotAPI) SendChatJoinRequestWebAppContext(ctx context.Context, chatJoinRequestQueryID, webAppURL string) (APIResponse, error) {
 	if chatJoinRequestQueryID == "" {
 		return APIResponse{}, errors.New("chat_join_request_query_id is required")
 	}
@@ -1071,44 +1329,71 @@ func (bot *BotAPI) SendChatJoinRequestWebApp(chatJoinRequestQueryID, webAppURL s
 
 	bot.debugLog("sendChatJoinRequestWebApp", v, nil)
 
-	return bot.MakeRequest("sendChatJoinRequestWebApp", v)
+	return bot.MakeRequestContext(ctx, "sendChatJoinRequestWebApp", v)
 }
 
 // EditEphemeralMessageText edits an ephemeral text message. Bot API 10.2+
 //
 // https://core.telegram.org/bots/api#editephemeralmessagetext
 func (bot *BotAPI) EditEphemeralMessageText(config EditEphemeralMessageTextConfig) (APIResponse, error) {
-	return bot.MakeRequestFromChattable(config)
+	return bot.EditEphemeralMessageTextContext(context.Background(), config)
+}
+
+// EditEphemeralMessageTextContext is EditEphemeralMessageText using ctx for the HTTP call.
+func (bot *BotAPI) EditEphemeralMessageTextContext(ctx context.Context, config EditEphemeralMessageTextConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
 }
 
 // EditEphemeralMessageMedia edits the media of an ephemeral message. Bot API 10.2+
 //
 // https://core.telegram.org/bots/api#editephemeralmessagemedia
 func (bot *BotAPI) EditEphemeralMessageMedia(config EditEphemeralMessageMediaConfig) (APIResponse, error) {
-	return bot.MakeRequestFromChattable(config)
+	return bot.EditEphemeralMessageMediaContext(context.Background(), config)
+}
+
+// EditEphemeralMessageMediaContext is EditEphemeralMessageMedia using ctx for the HTTP call.
+func (bot *BotAPI) EditEphemeralMessageMediaContext(ctx context.Context, config EditEphemeralMessageMediaConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
 }
 
 // EditEphemeralMessageCaption edits the caption of an ephemeral message. Bot API 10.2+
 //
 // https://core.telegram.org/bots/api#editephemeralmessagecaption
 func (bot *BotAPI) EditEphemeralMessageCaption(config EditEphemeralMessageCaptionConfig) (APIResponse, error) {
-	return bot.MakeRequestFromChattable(config)
+	return bot.EditEphemeralMessageCaptionContext(context.Background(), config)
+}
+
+// EditEphemeralMessageCaptionContext is EditEphemeralMessageCaption using ctx for the HTTP call.
+func (bot *BotAPI) EditEphemeralMessageCaptionContext(ctx context.Context, config EditEphemeralMessageCaptionConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
 }
 
 // EditEphemeralMessageReplyMarkup edits only the reply markup of an ephemeral message. Bot API 10.2+
 //
 // https://core.telegram.org/bots/api#editephemeralmessagereplymarkup
 func (bot *BotAPI) EditEphemeralMessageReplyMarkup(config EditEphemeralMessageReplyMarkupConfig) (APIResponse, error) {
-	return bot.MakeRequestFromChattable(config)
+	return bot.EditEphemeralMessageReplyMarkupContext(context.Background(), config)
+}
+
+// EditEphemeralMessageReplyMarkupContext is EditEphemeralMessageReplyMarkup using ctx for the HTTP call.
+func (bot *BotAPI) EditEphemeralMessageReplyMarkupContext(ctx context.Context, config EditEphemeralMessageReplyMarkupConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
 }
 
 // DeleteEphemeralMessage deletes an ephemeral message. Bot API 10.2+
 //
 // https://core.telegram.org/bots/api#deleteephemeralmessage
 func (bot *BotAPI) DeleteEphemeralMessage(config DeleteEphemeralMessageConfig) (APIResponse, error) {
-	return bot.MakeRequestFromChattable(config)
+	return bot.DeleteEphemeralMessageContext(context.Background(), config)
+}
+
+// DeleteEphemeralMessageContext is DeleteEphemeralMessage using ctx for the HTTP call.
+func (bot *BotAPI) DeleteEphemeralMessageContext(ctx context.Context, config DeleteEphemeralMessageConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
 }
 
+// SendCustomMessage sends config using ctx for the HTTP call and decodes the
+// Telegram result into result.
 func (bot *BotAPI) SendCustomMessage(ctx context.Context, config Sendable, result any) (err error) {
 	var values url.Values
 	if values, err = config.Values(); err != nil {
@@ -1116,7 +1401,7 @@ func (bot *BotAPI) SendCustomMessage(ctx context.Context, config Sendable, resul
 	}
 	telegramMethod := config.TelegramMethod()
 	var apiResponse APIResponse
-	apiResponse, err = bot.MakeRequest(telegramMethod, values)
+	apiResponse, err = bot.MakeRequestContext(ctx, telegramMethod, values)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,101 @@
+package tgbotapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// blockingTransport waits for the request context to finish, like a long-poll
+// or a stalled connection would.
+func blockingTransport() roundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	}
+}
+
+func TestMakeRequestContext_Cancelled(t *testing.T) {
+	const token = "123456:PRIVATE-BOT-TOKEN"
+	bot := NewBotAPIWithClient(token, &http.Client{Transport: blockingTransport()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := bot.MakeRequestContext(ctx, "getMe", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("MakeRequestContext() error = %v, want context.Canceled", err)
+	}
+	assertDoesNotContainPrivateValues(t, err.Error(), token)
+}
+
+func TestMakeRequestContext_SendsForm(t *testing.T) {
+	var gotContentType, gotBody string
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			gotContentType = r.Header.Get("Content-Type")
+			data, _ := io.ReadAll(r.Body)
+			gotBody = string(data)
+			body := `{"ok":true,"result":true}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	})
+
+	if _, err := bot.MakeRequestContext(context.Background(), "sendMessage", url.Values{"text": {"hi"}}); err != nil {
+		t.Fatalf("MakeRequestContext() error = %v", err)
+	}
+	if gotContentType != "application/x-www-form-urlencoded" {
+		t.Fatalf("Content-Type = %q, want application/x-www-form-urlencoded", gotContentType)
+	}
+	if gotBody != "text=hi" {
+		t.Fatalf("body = %q, want text=hi", gotBody)
+	}
+}
+
+func TestSendContext_DeadlineExceeded(t *testing.T) {
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{Transport: blockingTransport()})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := bot.SendContext(ctx, NewMessage(1, "hello"))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SendContext() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestUploadFileContext_Cancelled(t *testing.T) {
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{Transport: blockingTransport()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := bot.UploadFileContext(ctx, "sendPhoto", nil, "photo", FileBytes{Name: "photo.jpg", Bytes: []byte("image")})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("UploadFileContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestGetUpdatesContext_Cancelled(t *testing.T) {
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{Transport: blockingTransport()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := bot.GetUpdatesContext(ctx, &UpdateConfig{Timeout: 60})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetUpdatesContext() error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,92 @@
+package tgbotapi
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultBaseURL is the address of the cloud Bot API server.
+const DefaultBaseURL = "https://api.telegram.org"
+
+// ErrLocalFile happens when a direct URL is requested for a file stored on the
+// file system of a local Bot API server, see BotAPI.OpenFile.
+var ErrLocalFile = errors.New("file is stored locally by the Bot API server and has no URL")
+
+// botPath returns the path prefix of the bot on the Bot API server.
+func (bot *BotAPI) botPath() string {
+	if bot.TestEnvironment {
+		return "bot" + bot.Token + "/test"
+	}
+	return "bot" + bot.Token
+}
+
+// methodURL returns the URL of a Bot API method.
+func (bot *BotAPI) methodURL(method string) string {
+	baseURL := bot.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/" + bot.botPath() + "/" + method
+}
+
+// FileURL returns the download URL of a file returned by GetFile, on the
+// server set by FileBaseURL or BaseURL and in the test environment if
+// TestEnvironment is set. It fails with ErrLocalFile for a file stored by a
+// local Bot API server, see File.IsLocal.
+//
+// The URL contains the bot token, so it must not be logged or shared.
+func (bot *BotAPI) FileURL(file File) (string, error) {
+	return bot.fileURL(file.FilePath)
+}
+
+// fileURL returns the download URL of a file_path returned by getFile.
+func (bot *BotAPI) fileURL(filePath string) (string, error) {
+	if filepath.IsAbs(filePath) {
+		return "", ErrLocalFile
+	}
+	baseURL := bot.FileBaseURL
+	if baseURL == "" {
+		baseURL = bot.BaseURL
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/file/" + bot.botPath() + "/" + filePath, nil
+}
+
+// IsLocal reports whether FilePath is an absolute path on the file system of
+// a local Bot API server running in --local mode, rather than a path to
+// download the file from.
+func (f *File) IsLocal() bool {
+	return filepath.IsAbs(f.FilePath)
+}
+
+// LogOut logs the bot out from the cloud Bot API server before launching it
+// locally. The bot can't log in back to the cloud server for 10 minutes.
+//
+// https://core.telegram.org/bots/api#logout
+func (bot *BotAPI) LogOut() (APIResponse, error) {
+	return bot.LogOutContext(context.Background())
+}
+
+// LogOutContext is LogOut using ctx for the HTTP call.
+func (bot *BotAPI) LogOutContext(ctx context.Context) (APIResponse, error) {
+	return bot.MakeRequestContext(ctx, "logOut", url.Values{})
+}
+
+// Close closes the bot instance before moving it from one local server to
+// another. Delete the webhook before calling it. The method fails with
+// error 429 during the first 10 minutes after the bot is launched.
+//
+// https://core.telegram.org/bots/api#close
+func (bot *BotAPI) Close() (APIResponse, error) {
+	return bot.CloseContext(context.Background())
+}
+
+// CloseContext is Close using ctx for the HTTP call.
+func (bot *BotAPI) CloseContext(ctx context.Context) (APIResponse, error) {
+	return bot.MakeRequestContext(ctx, "close", url.Values{})
+}
@@ -0,0 +1,85 @@
+package tgbotapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBotAPI_EndpointURLs(t *testing.T) {
+	tests := []struct {
+		bot       BotAPI
+		methodURL string
+		fileURL   string
+	}{
+		{
+			BotAPI{Token: "T"},
+			"https://api.telegram.org/botT/getMe",
+			"https://api.telegram.org/file/botT/photos/1.jpg",
+		},
+		{
+			BotAPI{Token: "T", TestEnvironment: true},
+			"https://api.telegram.org/botT/test/getMe",
+			"https://api.telegram.org/file/botT/test/photos/1.jpg",
+		},
+		{
+			BotAPI{Token: "T", BaseURL: "http://localhost:8081/"},
+			"http://localhost:8081/botT/getMe",
+			"http://localhost:8081/file/botT/photos/1.jpg",
+		},
+		{
+			BotAPI{Token: "T", BaseURL: "http://localhost:8081", FileBaseURL: "https://files.example.com"},
+			"http://localhost:8081/botT/getMe",
+			"https://files.example.com/file/botT/photos/1.jpg",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.bot.methodURL("getMe"); got != tt.methodURL {
+			t.Errorf("methodURL() = %q, want %q", got, tt.methodURL)
+		}
+		if got, err := tt.bot.FileURL(File{FilePath: "photos/1.jpg"}); got != tt.fileURL || err != nil {
+			t.Errorf("FileURL() = %q, %v, want %q", got, err, tt.fileURL)
+		}
+	}
+	if got, want := (&BotAPI{Token: "T"}).methodURL("getMe"), "https://api.telegram.org/botT/getMe"; got != want {
+		t.Errorf("default methodURL() = %q, want APIEndpoint %q", got, want)
+	}
+}
+
+func TestBotAPI_LocalServer(t *testing.T) {
+	var requested []string
+	bot := NewBotAPIWithClient("T", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			requested = append(requested, r.URL.String())
+			body := `{"ok":true,"result":true}`
+			if strings.HasSuffix(r.URL.Path, "/getFile") {
+				body = `{"ok":true,"result":{"file_id":"a","file_path":"/var/lib/telegram-bot-api/T/photos/1.jpg"}}`
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(body))}, nil
+		}),
+	})
+	bot.BaseURL = "http://localhost:8081"
+
+	if _, err := bot.LogOut(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.GetFileDirectURL("a"); !errors.Is(err, ErrLocalFile) {
+		t.Fatalf("GetFileDirectURL() error = %v, want ErrLocalFile", err)
+	}
+	local := File{FilePath: "/var/lib/telegram-bot-api/T/photos/1.jpg"}
+	if _, err := bot.FileURL(local); !errors.Is(err, ErrLocalFile) {
+		t.Fatalf("FileURL() error = %v, want ErrLocalFile", err)
+	}
+	if link := local.Link("T"); link != "" {
+		t.Fatalf("Link() = %q for a local file", link)
+	}
+	want := []string{"http://localhost:8081/botT/logOut", "http://localhost:8081/botT/close", "http://localhost:8081/botT/getFile"}
+	if strings.Join(requested, " ") != strings.Join(want, " ") {
+		t.Fatalf("requested %v, want %v", requested, want)
+	}
+}
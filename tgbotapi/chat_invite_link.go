@@ -0,0 +1,324 @@
+package tgbotapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ChatSubscriptionPeriod is the only subscription period, in seconds, that
+// createChatSubscriptionInviteLink accepts: 30 days.
+const ChatSubscriptionPeriod = 2592000
+
+// ChatInviteLink represents an invite link for a chat.
+//
+// https://core.telegram.org/bots/api#chatinvitelink
+type ChatInviteLink struct {
+	// The invite link. If the link was created by another chat administrator, then the second part of the link will be replaced with "…"
+	InviteLink string `json:"invite_link"`
+
+	// Creator of the link
+	Creator User `json:"creator"`
+
+	// True, if users joining the chat via the link need to be approved by chat administrators
+	CreatesJoinRequest bool `json:"creates_join_request"`
+
+	// True, if the link is primary
+	IsPrimary bool `json:"is_primary"`
+
+	// True, if the link is revoked
+	IsRevoked bool `json:"is_revoked"`
+
+	// Optional. Invite link name
+	Name string `json:"name,omitempty"`
+
+	// Optional. Point in time (Unix timestamp) when the link will expire or has been expired
+	ExpireDate int `json:"expire_date,omitempty"`
+
+	// Optional. The maximum number of users that can be members of the chat simultaneously after joining the chat via this invite link; 1-99999
+	MemberLimit int `json:"member_limit,omitempty"`
+
+	// Optional. Number of pending join requests created using this link
+	PendingJoinRequestCount int `json:"pending_join_request_count,omitempty"`
+
+	// Optional. The number of seconds the subscription will be active for before the next payment
+	SubscriptionPeriod int `json:"subscription_period,omitempty"`
+
+	// Optional. The amount of Telegram Stars a user must pay initially and after each subsequent subscription period to be a member of the chat using the link
+	SubscriptionPrice int `json:"subscription_price,omitempty"`
+}
+
+// chatInviteLinkOptions validates and adds the options shared by
+// createChatInviteLink and editChatInviteLink.
+func chatInviteLinkOptions(values url.Values, name string, expireDate, memberLimit int, createsJoinRequest bool) error {
+	if n := len([]rune(name)); n > 32 {
+		return fmt.Errorf("invite link name is %d characters long, the maximum is 32", n)
+	}
+	if memberLimit != 0 {
+		if createsJoinRequest {
+			return errors.New("member limit can't be set for an invite link that creates join requests")
+		}
+		if memberLimit < 1 || memberLimit > 99999 {
+			return fmt.Errorf("member limit must be 1-99999, got %d", memberLimit)
+		}
+		values.Add("member_limit", strconv.Itoa(memberLimit))
+	}
+	if name != "" {
+		values.Add("name", name)
+	}
+	if expireDate != 0 {
+		values.Add("expire_date", strconv.Itoa(expireDate))
+	}
+	if createsJoinRequest {
+		values.Add("creates_join_request", "true")
+	}
+	return nil
+}
+
+// CreateChatInviteLinkConfig contains information about a createChatInviteLink
+// request. The bot must be an administrator with the can_invite_users right.
+//
+// https://core.telegram.org/bots/api#createchatinvitelink
+type CreateChatInviteLinkConfig struct {
+	ChatConfig
+
+	// Invite link name; 0-32 characters
+	Name string
+
+	// Point in time (Unix timestamp) when the link will expire
+	ExpireDate int
+
+	// The maximum number of users that can be members of the chat simultaneously after joining the chat via this invite link; 1-99999
+	MemberLimit int
+
+	// True, if users joining the chat via the link need to be approved by chat administrators. If True, MemberLimit can't be specified
+	CreatesJoinRequest bool
+}
+
+// Values returns url.Values representation of CreateChatInviteLinkConfig.
+func (c CreateChatInviteLinkConfig) Values() (url.Values, error) {
+	values := c.ChatConfig.values()
+	if err := chatInviteLinkOptions(values, c.Name, c.ExpireDate, c.MemberLimit, c.CreatesJoinRequest); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for creating an invite link.
+func (CreateChatInviteLinkConfig) TelegramMethod() string {
+	return "createChatInviteLink"
+}
+
+// EditChatInviteLinkConfig contains information about an editChatInviteLink
+// request for a non-primary link created by the bot.
+//
+// https://core.telegram.org/bots/api#editchatinvitelink
+type EditChatInviteLinkConfig struct {
+	ChatConfig
+
+	// The invite link to edit, required
+	InviteLink string
+
+	// Invite link name; 0-32 characters
+	Name string
+
+	// Point in time (Unix timestamp) when the link will expire
+	ExpireDate int
+
+	// The maximum number of users that can be members of the chat simultaneously after joining the chat via this invite link; 1-99999
+	MemberLimit int
+
+	// True, if users joining the chat via the link need to be approved by chat administrators. If True, MemberLimit can't be specified
+	CreatesJoinRequest bool
+}
+
+// Values returns url.Values representation of EditChatInviteLinkConfig.
+func (c EditChatInviteLinkConfig) Values() (url.Values, error) {
+	if c.InviteLink == "" {
+		return nil, errors.New("invite link is required")
+	}
+	values := c.ChatConfig.values()
+	values.Add("invite_link", c.InviteLink)
+	if err := chatInviteLinkOptions(values, c.Name, c.ExpireDate, c.MemberLimit, c.CreatesJoinRequest); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for editing an invite link.
+func (EditChatInviteLinkConfig) TelegramMethod() string {
+	return "editChatInviteLink"
+}
+
+// CreateChatSubscriptionInviteLinkConfig contains information about a
+// createChatSubscriptionInviteLink request for a channel chat. The bot must
+// have the can_invite_users administrator right.
+//
+// https://core.telegram.org/bots/api#createchatsubscriptioninvitelink
+type CreateChatSubscriptionInviteLinkConfig struct {
+	ChatConfig
+
+	// Invite link name; 0-32 characters
+	Name string
+
+	// The number of seconds the subscription will be active for before the
+	// next payment. 0 means ChatSubscriptionPeriod, the only supported value
+	SubscriptionPeriod int
+
+	// The amount of Telegram Stars a user must pay initially and after each subsequent subscription period to be a member of the chat; 1-10000
+	SubscriptionPrice int
+}
+
+// Values returns url.Values representation of CreateChatSubscriptionInviteLinkConfig.
+func (c CreateChatSubscriptionInviteLinkConfig) Values() (url.Values, error) {
+	period := c.SubscriptionPeriod
+	if period == 0 {
+		period = ChatSubscriptionPeriod
+	}
+	if period != ChatSubscriptionPeriod {
+		return nil, fmt.Errorf("subscription period must be %d seconds, got %d", ChatSubscriptionPeriod, period)
+	}
+	if c.SubscriptionPrice < 1 || c.SubscriptionPrice > 10000 {
+		return nil, fmt.Errorf("subscription price must be 1-10000 Telegram Stars, got %d", c.SubscriptionPrice)
+	}
+	values := c.ChatConfig.values()
+	if err := chatInviteLinkOptions(values, c.Name, 0, 0, false); err != nil {
+		return nil, err
+	}
+	values.Add("subscription_period", strconv.Itoa(period))
+	values.Add("subscription_price", strconv.Itoa(c.SubscriptionPrice))
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for creating a subscription invite link.
+func (CreateChatSubscriptionInviteLinkConfig) TelegramMethod() string {
+	return "createChatSubscriptionInviteLink"
+}
+
+// RevokeChatInviteLinkConfig contains information about a revokeChatInviteLink
+// request. If the primary link is revoked, a new link is automatically generated.
+//
+// https://core.telegram.org/bots/api#revokechatinvitelink
+type RevokeChatInviteLinkConfig struct {
+	ChatConfig
+
+	// The invite link to revoke, required
+	InviteLink string
+}
+
+// Values returns url.Values representation of RevokeChatInviteLinkConfig.
+func (c RevokeChatInviteLinkConfig) Values() (url.Values, error) {
+	if c.InviteLink == "" {
+		return nil, errors.New("invite link is required")
+	}
+	values := c.ChatConfig.values()
+	values.Add("invite_link", c.InviteLink)
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for revoking an invite link.
+func (RevokeChatInviteLinkConfig) TelegramMethod() string {
+	return "revokeChatInviteLink"
+}
+
+// ChatJoinRequestConfig identifies a chat join request to approve or decline,
+// see BotAPI.ApproveChatJoinRequest.
+type ChatJoinRequestConfig struct {
+	ChatConfig
+
+	// Unique identifier of the user who sent the join request
+	UserID int64
+}
+
+// NewChatJoinRequestConfig returns the config to approve or decline request.
+func NewChatJoinRequestConfig(request ChatJoinRequest) ChatJoinRequestConfig {
+	return ChatJoinRequestConfig{ChatConfig: ChatConfig{ChatID: request.Chat.ID}, UserID: request.From.ID}
+}
+
+func (c ChatJoinRequestConfig) values() url.Values {
+	values := c.ChatConfig.values()
+	values.Add("user_id", strconv.FormatInt(c.UserID, 10))
+	return values
+}
+
+var (
+	_ Sendable = CreateChatInviteLinkConfig{}
+	_ Sendable = EditChatInviteLinkConfig{}
+	_ Sendable = CreateChatSubscriptionInviteLinkConfig{}
+	_ Sendable = RevokeChatInviteLinkConfig{}
+)
+
+// CreateChatInviteLink creates an additional invite link for a chat.
+// The link can be revoked with RevokeChatInviteLink.
+func (bot *BotAPI) CreateChatInviteLink(config CreateChatInviteLinkConfig) (ChatInviteLink, error) {
+	return bot.CreateChatInviteLinkContext(context.Background(), config)
+}
+
+// CreateChatInviteLinkContext is CreateChatInviteLink using ctx for the HTTP call.
+func (bot *BotAPI) CreateChatInviteLinkContext(ctx context.Context, config CreateChatInviteLinkConfig) (link ChatInviteLink, err error) {
+	err = bot.SendCustomMessage(ctx, config, &link)
+	return
+}
+
+// EditChatInviteLink edits a non-primary invite link created by the bot.
+func (bot *BotAPI) EditChatInviteLink(config EditChatInviteLinkConfig) (ChatInviteLink, error) {
+	return bot.EditChatInviteLinkContext(context.Background(), config)
+}
+
+// EditChatInviteLinkContext is EditChatInviteLink using ctx for the HTTP call.
+func (bot *BotAPI) EditChatInviteLinkContext(ctx context.Context, config EditChatInviteLinkConfig) (link ChatInviteLink, err error) {
+	err = bot.SendCustomMessage(ctx, config, &link)
+	return
+}
+
+// CreateChatSubscriptionInviteLink creates a subscription invite link for a
+// channel chat, paid in Telegram Stars.
+func (bot *BotAPI) CreateChatSubscriptionInviteLink(config CreateChatSubscriptionInviteLinkConfig) (ChatInviteLink, error) {
+	return bot.CreateChatSubscriptionInviteLinkContext(context.Background(), config)
+}
+
+// CreateChatSubscriptionInviteLinkContext is CreateChatSubscriptionInviteLink using ctx for the HTTP call.
+func (bot *BotAPI) CreateChatSubscriptionInviteLinkContext(ctx context.Context, config CreateChatSubscriptionInviteLinkConfig) (link ChatInviteLink, err error) {
+	err = bot.SendCustomMessage(ctx, config, &link)
+	return
+}
+
+// RevokeChatInviteLink revokes an invite link created by the bot and returns
+// the revoked link.
+func (bot *BotAPI) RevokeChatInviteLink(config RevokeChatInviteLinkConfig) (ChatInviteLink, error) {
+	return bot.RevokeChatInviteLinkContext(context.Background(), config)
+}
+
+// RevokeChatInviteLinkContext is RevokeChatInviteLink using ctx for the HTTP call.
+func (bot *BotAPI) RevokeChatInviteLinkContext(ctx context.Context, config RevokeChatInviteLinkConfig) (link ChatInviteLink, err error) {
+	err = bot.SendCustomMessage(ctx, config, &link)
+	return
+}
+
+// ApproveChatJoinRequest approves a chat join request. The bot must be an
+// administrator with the can_invite_users right.
+//
+// https://core.telegram.org/bots/api#approvechatjoinrequest
+func (bot *BotAPI) ApproveChatJoinRequest(config ChatJoinRequestConfig) (APIResponse, error) {
+	return bot.ApproveChatJoinRequestContext(context.Background(), config)
+}
+
+// ApproveChatJoinRequestContext is ApproveChatJoinRequest using ctx for the HTTP call.
+func (bot *BotAPI) ApproveChatJoinRequestContext(ctx context.Context, config ChatJoinRequestConfig) (APIResponse, error) {
+	return bot.MakeRequestContext(ctx, "approveChatJoinRequest", config.values())
+}
+
+// DeclineChatJoinRequest declines a chat join request. The bot must be an
+// administrator with the can_invite_users right.
+//
+// https://core.telegram.org/bots/api#declinechatjoinrequest
+func (bot *BotAPI) DeclineChatJoinRequest(config ChatJoinRequestConfig) (APIResponse, error) {
+	return bot.DeclineChatJoinRequestContext(context.Background(), config)
+}
+
+// DeclineChatJoinRequestContext is DeclineChatJoinRequest using ctx for the HTTP call.
+func (bot *BotAPI) DeclineChatJoinRequestContext(ctx context.Context, config ChatJoinRequestConfig) (APIResponse, error) {
+	return bot.MakeRequestContext(ctx, "declineChatJoinRequest", config.values())
+}
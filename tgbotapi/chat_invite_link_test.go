@@ -0,0 +1,65 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestChatInviteLinkConfigs_Values(t *testing.T) {
+	values, err := CreateChatInviteLinkConfig{ChatConfig: ChatConfig{ChatID: -100}, Name: "beta", ExpireDate: 1700000000, MemberLimit: 10}.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Get("name") != "beta" || values.Get("expire_date") != "1700000000" || values.Get("member_limit") != "10" {
+		t.Errorf("values = %v", values)
+	}
+
+	values, err = CreateChatSubscriptionInviteLinkConfig{ChatConfig: ChatConfig{ChannelUsername: "@channel"}, SubscriptionPrice: 50}.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Get("subscription_period") != "2592000" || values.Get("subscription_price") != "50" || values.Get("chat_id") != "@channel" {
+		t.Errorf("values = %v", values)
+	}
+
+	for name, config := range map[string]Sendable{
+		"limit_with_join_request": CreateChatInviteLinkConfig{MemberLimit: 10, CreatesJoinRequest: true},
+		"limit_too_large":         CreateChatInviteLinkConfig{MemberLimit: 100000},
+		"long_name":               CreateChatInviteLinkConfig{Name: "a name that is longer than 32 chars"},
+		"edit_without_link":       EditChatInviteLinkConfig{Name: "x"},
+		"revoke_without_link":     RevokeChatInviteLinkConfig{},
+		"subscription_period":     CreateChatSubscriptionInviteLinkConfig{SubscriptionPeriod: 3600, SubscriptionPrice: 1},
+		"subscription_price":      CreateChatSubscriptionInviteLinkConfig{SubscriptionPrice: 10001},
+	} {
+		if _, err = config.Values(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCreateChatInviteLink(t *testing.T) {
+	bot := testBotWithResponse("123456:TOKEN", http.StatusOK,
+		`{"ok":true,"result":{"invite_link":"https://t.me/+abc","creator":{"id":1,"is_bot":true,"first_name":"Bot"},"creates_join_request":true,"is_primary":false,"is_revoked":false,"pending_join_request_count":2}}`)
+	link, err := bot.CreateChatInviteLink(CreateChatInviteLinkConfig{ChatConfig: ChatConfig{ChatID: -100}, CreatesJoinRequest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.InviteLink != "https://t.me/+abc" || !link.CreatesJoinRequest || link.PendingJoinRequestCount != 2 || link.Creator.ID != 1 {
+		t.Errorf("link = %+v", link)
+	}
+}
+
+func TestChatJoinRequest_InviteLinkAndConfig(t *testing.T) {
+	var request ChatJoinRequest
+	if err := json.Unmarshal([]byte(`{"chat":{"id":-100,"type":"supergroup"},"from":{"id":7,"first_name":"A"},"user_chat_id":7,"date":1,
+		"invite_link":{"invite_link":"https://t.me/+abc","creator":{"id":1,"first_name":"Bot"},"creates_join_request":true,"is_primary":false,"is_revoked":false,"name":"beta"}}`), &request); err != nil {
+		t.Fatal(err)
+	}
+	if request.InviteLink == nil || request.InviteLink.Name != "beta" {
+		t.Errorf("invite_link = %+v", request.InviteLink)
+	}
+	if got := NewChatJoinRequestConfig(request).values(); got.Get("chat_id") != "-100" || got.Get("user_id") != "7" {
+		t.Errorf("values = %v", got)
+	}
+}
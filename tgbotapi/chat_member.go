@@ -0,0 +1,362 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ChatMemberStatus represents the status of a chat member.
+type ChatMemberStatus string
+
+const (
+	ChatMemberStatusCreator       ChatMemberStatus = "creator"
+	ChatMemberStatusAdministrator ChatMemberStatus = "administrator"
+	ChatMemberStatusMember        ChatMemberStatus = "member"
+	ChatMemberStatusRestricted    ChatMemberStatus = "restricted"
+	ChatMemberStatusLeft          ChatMemberStatus = "left"
+	ChatMemberStatusKicked        ChatMemberStatus = "kicked"
+)
+
+type chatMember struct {
+	// The member's status in the chat
+	Status ChatMemberStatus `json:"status"`
+
+	// Information about the user
+	User *User `json:"user"`
+}
+
+// ChatMember contains information about one member of a chat. It is one of
+// *ChatMemberOwner, *ChatMemberAdministrator, *ChatMemberMember,
+// *ChatMemberRestricted, *ChatMemberLeft or *ChatMemberBanned when decoded
+// from JSON:
+//
+//	switch m := member.(type) {
+//	case *tgbotapi.ChatMemberAdministrator:
+//		log.Println(m.CustomTitle, m.CanRestrictMembers)
+//	case *tgbotapi.ChatMemberRestricted:
+//		log.Println("restricted until", m.UntilDate)
+//	}
+//
+// https://core.telegram.org/bots/api#chatmember
+type ChatMember interface {
+	ChatMemberStatus() ChatMemberStatus
+
+	// MemberUser returns information about the user.
+	MemberUser() *User
+
+	// IsAdmin reports whether the member is the creator or an administrator of the chat.
+	IsAdmin() bool
+
+	// CanRestrict reports whether the member may restrict, ban or unban other members.
+	CanRestrict() bool
+
+	// AdministratorRights returns the rights of an administrator, all rights
+	// for the creator and no rights for other members.
+	AdministratorRights() ChatAdministratorRights
+
+	// Permissions returns what the member may do in the chat as far as their
+	// own status is concerned: the explicit permissions of a restricted
+	// member, the rights of an administrator, everything for the creator and
+	// unrestricted members, and nothing for members that left or were banned.
+	//
+	// The default permissions of the chat still apply to unrestricted members.
+	Permissions() ChatPermissions
+}
+
+// unmarshalChatMember decodes a ChatMember variant based on its "status".
+// Members of a status unknown to this package are decoded as nil.
+func unmarshalChatMember(data json.RawMessage) (ChatMember, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var head chatMember
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChatMember status: %w", err)
+	}
+	var member ChatMember
+	switch head.Status {
+	case ChatMemberStatusCreator:
+		member = &ChatMemberOwner{}
+	case ChatMemberStatusAdministrator:
+		member = &ChatMemberAdministrator{}
+	case ChatMemberStatusMember:
+		member = &ChatMemberMember{}
+	case ChatMemberStatusRestricted:
+		member = &ChatMemberRestricted{}
+	case ChatMemberStatusLeft:
+		member = &ChatMemberLeft{}
+	case ChatMemberStatusKicked:
+		member = &ChatMemberBanned{}
+	default:
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, member); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChatMember of status %q: %w", head.Status, err)
+	}
+	return member, nil
+}
+
+// unmarshalChatMembers decodes a list of ChatMember variants, skipping
+// members of an unknown status.
+func unmarshalChatMembers(data json.RawMessage) ([]ChatMember, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	members := make([]ChatMember, 0, len(raw))
+	for _, item := range raw {
+		member, err := unmarshalChatMember(item)
+		if err != nil {
+			return nil, err
+		}
+		if member != nil {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (m chatMember) MemberUser() *User {
+	return m.User
+}
+
+func (chatMember) IsAdmin() bool {
+	return false
+}
+
+func (chatMember) CanRestrict() bool {
+	return false
+}
+
+func (chatMember) AdministratorRights() ChatAdministratorRights {
+	return ChatAdministratorRights{}
+}
+
+func (chatMember) Permissions() ChatPermissions {
+	return ChatPermissions{}
+}
+
+var _ ChatMember = (*ChatMemberOwner)(nil)
+
+// ChatMemberOwner is a chat member that owns the chat and has all administrator privileges.
+// https://core.telegram.org/bots/api#chatmemberowner
+type ChatMemberOwner struct {
+	chatMember
+
+	// True, if the user's presence in the chat is hidden
+	IsAnonymous bool `json:"is_anonymous"`
+
+	// Optional. Custom title for this user
+	CustomTitle string `json:"custom_title,omitempty"`
+}
+
+func (ChatMemberOwner) ChatMemberStatus() ChatMemberStatus {
+	return ChatMemberStatusCreator
+}
+
+// MarshalJSON always encodes the "creator" status.
+func (m ChatMemberOwner) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberOwner
+	m.Status = ChatMemberStatusCreator
+	return json.Marshal(alias(m))
+}
+
+func (ChatMemberOwner) IsAdmin() bool {
+	return true
+}
+
+func (ChatMemberOwner) CanRestrict() bool {
+	return true
+}
+
+func (m ChatMemberOwner) AdministratorRights() ChatAdministratorRights {
+	return ChatAdministratorRights{
+		IsAnonymous:             m.IsAnonymous,
+		CanManageChat:           true,
+		CanDeleteMessages:       true,
+		CanManageVideoChats:     true,
+		CanRestrictMembers:      true,
+		CanPromoteMembers:       true,
+		CanChangeInfo:           true,
+		CanInviteUsers:          true,
+		CanPostMessages:         true,
+		CanEditMessages:         true,
+		CanPinMessages:          true,
+		CanPostStories:          true,
+		CanEditStories:          true,
+		CanDeleteStories:        true,
+		CanManageTopics:         true,
+		CanManageDirectMessages: true,
+		CanManageTags:           true,
+	}
+}
+
+func (ChatMemberOwner) Permissions() ChatPermissions {
+	return allChatPermissions(true, true, true, true)
+}
+
+var _ ChatMember = (*ChatMemberAdministrator)(nil)
+
+// ChatMemberAdministrator is a chat member that has some additional privileges.
+// https://core.telegram.org/bots/api#chatmemberadministrator
+type ChatMemberAdministrator struct {
+	chatMember
+
+	// True, if the bot is allowed to edit administrator privileges of that user
+	CanBeEdited bool `json:"can_be_edited"`
+
+	// The administrator privileges of the user
+	ChatAdministratorRights
+
+	// Optional. Custom title for this user
+	CustomTitle string `json:"custom_title,omitempty"`
+}
+
+func (ChatMemberAdministrator) ChatMemberStatus() ChatMemberStatus {
+	return ChatMemberStatusAdministrator
+}
+
+// MarshalJSON always encodes the "administrator" status.
+func (m ChatMemberAdministrator) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberAdministrator
+	m.Status = ChatMemberStatusAdministrator
+	return json.Marshal(alias(m))
+}
+
+func (ChatMemberAdministrator) IsAdmin() bool {
+	return true
+}
+
+func (m ChatMemberAdministrator) CanRestrict() bool {
+	return m.CanRestrictMembers
+}
+
+func (m ChatMemberAdministrator) AdministratorRights() ChatAdministratorRights {
+	return m.ChatAdministratorRights
+}
+
+func (m ChatMemberAdministrator) Permissions() ChatPermissions {
+	return allChatPermissions(m.CanChangeInfo, m.CanInviteUsers, m.CanPinMessages, m.CanManageTopics)
+}
+
+var _ ChatMember = (*ChatMemberMember)(nil)
+
+// ChatMemberMember is a chat member that has no additional privileges or restrictions.
+// https://core.telegram.org/bots/api#chatmembermember
+type ChatMemberMember struct {
+	chatMember
+
+	// Optional. Date when the user's subscription will expire; Unix time
+	UntilDate int `json:"until_date,omitempty"`
+}
+
+func (ChatMemberMember) ChatMemberStatus() ChatMemberStatus {
+	return ChatMemberStatusMember
+}
+
+// MarshalJSON always encodes the "member" status.
+func (m ChatMemberMember) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberMember
+	m.Status = ChatMemberStatusMember
+	return json.Marshal(alias(m))
+}
+
+func (ChatMemberMember) Permissions() ChatPermissions {
+	return allChatPermissions(true, true, true, true)
+}
+
+var _ ChatMember = (*ChatMemberRestricted)(nil)
+
+// ChatMemberRestricted is a chat member that is under certain restrictions in the chat. Supergroups only.
+// https://core.telegram.org/bots/api#chatmemberrestricted
+type ChatMemberRestricted struct {
+	chatMember
+
+	// True, if the user is a member of the chat at the moment of the request
+	IsMember bool `json:"is_member"`
+
+	// What the user is allowed to do in the chat
+	ChatPermissions
+
+	// Date when restrictions will be lifted for this user; Unix time. If 0, then the user is restricted forever
+	UntilDate int `json:"until_date"`
+}
+
+func (ChatMemberRestricted) ChatMemberStatus() ChatMemberStatus {
+	return ChatMemberStatusRestricted
+}
+
+// MarshalJSON always encodes the "restricted" status.
+func (m ChatMemberRestricted) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberRestricted
+	m.Status = ChatMemberStatusRestricted
+	return json.Marshal(alias(m))
+}
+
+func (m ChatMemberRestricted) Permissions() ChatPermissions {
+	return m.ChatPermissions
+}
+
+var _ ChatMember = (*ChatMemberLeft)(nil)
+
+// ChatMemberLeft is a chat member that isn't currently a member of the chat, but may join it themselves.
+// https://core.telegram.org/bots/api#chatmemberleft
+type ChatMemberLeft struct {
+	chatMember
+}
+
+func (ChatMemberLeft) ChatMemberStatus() ChatMemberStatus {
+	return ChatMemberStatusLeft
+}
+
+// MarshalJSON always encodes the "left" status.
+func (m ChatMemberLeft) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberLeft
+	m.Status = ChatMemberStatusLeft
+	return json.Marshal(alias(m))
+}
+
+var _ ChatMember = (*ChatMemberBanned)(nil)
+
+// ChatMemberBanned is a chat member that was banned in the chat and can't return to the chat or view chat messages.
+// https://core.telegram.org/bots/api#chatmemberbanned
+type ChatMemberBanned struct {
+	chatMember
+
+	// Date when restrictions will be lifted for this user; Unix time. If 0, then the user is banned forever
+	UntilDate int `json:"until_date"`
+}
+
+func (ChatMemberBanned) ChatMemberStatus() ChatMemberStatus {
+	return ChatMemberStatusKicked
+}
+
+// MarshalJSON always encodes the "kicked" status.
+func (m ChatMemberBanned) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberBanned
+	m.Status = ChatMemberStatusKicked
+	return json.Marshal(alias(m))
+}
+
+// allChatPermissions returns ChatPermissions allowing to send everything, with
+// the chat management permissions given explicitly.
+func allChatPermissions(changeInfo, inviteUsers, pinMessages, manageTopics bool) ChatPermissions {
+	return ChatPermissions{
+		CanSendMessages:       true,
+		CanSendAudios:         true,
+		CanSendDocuments:      true,
+		CanSendPhotos:         true,
+		CanSendVideos:         true,
+		CanSendVideoNotes:     true,
+		CanSendVoiceNotes:     true,
+		CanSendPolls:          true,
+		CanSendOtherMessages:  true,
+		CanAddWebPagePreviews: true,
+		CanReactToMessages:    true,
+		CanEditTag:            true,
+		CanChangeInfo:         changeInfo,
+		CanInviteUsers:        inviteUsers,
+		CanPinMessages:        pinMessages,
+		CanManageTopics:       manageTopics,
+	}
+}
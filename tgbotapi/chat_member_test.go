@@ -0,0 +1,91 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestChatMember_Variants(t *testing.T) {
+	tests := []struct {
+		json        string
+		want        ChatMember
+		isAdmin     bool
+		canRestrict bool
+		canSend     bool
+		canPin      bool
+	}{
+		{`{"status":"creator","user":{"id":1},"is_anonymous":true,"custom_title":"boss"}`, &ChatMemberOwner{}, true, true, true, true},
+		{`{"status":"administrator","user":{"id":2},"can_restrict_members":true,"can_pin_messages":false}`, &ChatMemberAdministrator{}, true, true, true, false},
+		{`{"status":"administrator","user":{"id":3},"can_manage_chat":true}`, &ChatMemberAdministrator{}, true, false, true, false},
+		{`{"status":"member","user":{"id":4},"until_date":100}`, &ChatMemberMember{}, false, false, true, true},
+		{`{"status":"restricted","user":{"id":5},"is_member":true,"can_send_messages":false,"can_pin_messages":true}`, &ChatMemberRestricted{}, false, false, false, true},
+		{`{"status":"left","user":{"id":6}}`, &ChatMemberLeft{}, false, false, false, false},
+		{`{"status":"kicked","user":{"id":7},"until_date":0}`, &ChatMemberBanned{}, false, false, false, false},
+	}
+	for i, tt := range tests {
+		member, err := unmarshalChatMember(json.RawMessage(tt.json))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.json, err)
+		}
+		if reflect.TypeOf(member) != reflect.TypeOf(tt.want) {
+			t.Fatalf("%s: decoded as %T, want %T", tt.json, member, tt.want)
+		}
+		if member.IsAdmin() != tt.isAdmin || member.CanRestrict() != tt.canRestrict {
+			t.Errorf("%s: IsAdmin=%v CanRestrict=%v", tt.json, member.IsAdmin(), member.CanRestrict())
+		}
+		permissions := member.Permissions()
+		if permissions.CanSendMessages != tt.canSend || permissions.CanPinMessages != tt.canPin {
+			t.Errorf("%s: Permissions() = %+v", tt.json, permissions)
+		}
+		if member.MemberUser() == nil || member.MemberUser().ID != int64(i+1) {
+			t.Errorf("%s: MemberUser() = %+v", tt.json, member.MemberUser())
+		}
+		data, err := json.Marshal(member)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again, err := unmarshalChatMember(data); err != nil || !reflect.DeepEqual(again, member) {
+			t.Errorf("%s: round trip = %s", tt.json, data)
+		}
+	}
+
+	if member, err := unmarshalChatMember(json.RawMessage(`{"status":"unknown","user":{"id":1}}`)); member != nil || err != nil {
+		t.Errorf("unknown status: member = %+v, err = %v", member, err)
+	}
+}
+
+func TestChatMember_AdministratorRights(t *testing.T) {
+	member, err := unmarshalChatMember(json.RawMessage(`{"status":"creator","user":{"id":1},"custom_title":"boss"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := member.(*ChatMemberOwner)
+	if owner.CustomTitle != "boss" || !owner.AdministratorRights().CanPromoteMembers {
+		t.Fatalf("owner = %+v", owner)
+	}
+	if rights := (ChatMemberMember{}).AdministratorRights(); rights != (ChatAdministratorRights{}) {
+		t.Fatalf("member rights = %+v, want none", rights)
+	}
+	admin := ChatMemberAdministrator{ChatAdministratorRights: ChatAdministratorRights{CanInviteUsers: true}}
+	if rights := admin.AdministratorRights(); !rights.CanInviteUsers || rights.CanPromoteMembers {
+		t.Fatalf("administrator rights = %+v", rights)
+	}
+}
+
+func TestChatMemberUpdated_Decode(t *testing.T) {
+	var update Update
+	data := `{"update_id":1,"chat_member":{"chat":{"id":-1,"type":"group"},"from":{"id":1},"date":0,
+		"old_chat_member":{"status":"member","user":{"id":2}},
+		"new_chat_member":{"status":"kicked","user":{"id":2},"until_date":42}}}`
+	if err := json.Unmarshal([]byte(data), &update); err != nil {
+		t.Fatal(err)
+	}
+	updated := update.ChatMember
+	if updated.OldChatMember.ChatMemberStatus() != ChatMemberStatusMember {
+		t.Fatalf("OldChatMember = %+v", updated.OldChatMember)
+	}
+	if banned, ok := updated.NewChatMember.(*ChatMemberBanned); !ok || banned.UntilDate != 42 || banned.User.ID != 2 {
+		t.Fatalf("NewChatMember = %+v", updated.NewChatMember)
+	}
+}
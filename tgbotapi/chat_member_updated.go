@@ -1,39 +1,48 @@
 package tgbotapi
 
-// ChatMemberStatus represents the status of a chat member.
-type ChatMemberStatus string
-
-const (
-	ChatMemberStatusCreator       ChatMemberStatus = "creator"
-	ChatMemberStatusAdministrator ChatMemberStatus = "administrator"
-	ChatMemberStatusMember        ChatMemberStatus = "member"
-	ChatMemberStatusRestricted    ChatMemberStatus = "restricted"
-	ChatMemberStatusLeft          ChatMemberStatus = "left"
-	ChatMemberStatusKicked        ChatMemberStatus = "kicked"
-)
+import "encoding/json"
 
 // ChatMemberUpdated represents changes in the status of a chat member.
 // https://core.telegram.org/bots/api#chatmemberupdated
 type ChatMemberUpdated struct {
-	Chat                    Chat        `json:"chat"`
-	From                    User        `json:"from"`
-	Date                    int         `json:"date"`
-	OldChatMember           interface{} `json:"old_chat_member"`
-	NewChatMember           interface{} `json:"new_chat_member"`
-	InviteLink              interface{} `json:"invite_link,omitempty"`
-	ViaJoinRequest          bool        `json:"via_join_request,omitempty"`
-	ViaChatFolderInviteLink bool        `json:"via_chat_folder_invite_link,omitempty"`
+	Chat                    Chat            `json:"chat"`
+	From                    User            `json:"from"`
+	Date                    int             `json:"date"`
+	OldChatMember           ChatMember      `json:"old_chat_member"`
+	NewChatMember           ChatMember      `json:"new_chat_member"`
+	InviteLink              *ChatInviteLink `json:"invite_link,omitempty"`
+	ViaJoinRequest          bool            `json:"via_join_request,omitempty"`
+	ViaChatFolderInviteLink bool            `json:"via_chat_folder_invite_link,omitempty"`
+}
+
+// UnmarshalJSON decodes OldChatMember and NewChatMember into their ChatMember variants.
+func (u *ChatMemberUpdated) UnmarshalJSON(data []byte) error {
+	type alias ChatMemberUpdated
+	aux := struct {
+		*alias
+		OldChatMember json.RawMessage `json:"old_chat_member"`
+		NewChatMember json.RawMessage `json:"new_chat_member"`
+	}{alias: (*alias)(u)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if u.OldChatMember, err = unmarshalChatMember(aux.OldChatMember); err != nil {
+		return err
+	}
+	u.NewChatMember, err = unmarshalChatMember(aux.NewChatMember)
+	return err
 }
 
 // ChatJoinRequest represents a join request sent to a chat.
 // https://core.telegram.org/bots/api#chatjoinrequest
 type ChatJoinRequest struct {
-	Chat       Chat        `json:"chat"`
-	From       User        `json:"from"`
-	UserChatID int64       `json:"user_chat_id"`
-	Date       int         `json:"date"`
-	Bio        string      `json:"bio,omitempty"`
-	InviteLink interface{} `json:"invite_link,omitempty"`
+	Chat       Chat            `json:"chat"`
+	From       User            `json:"from"`
+	UserChatID int64           `json:"user_chat_id"`
+	Date       int             `json:"date"`
+	Bio        string          `json:"bio,omitempty"`
+	InviteLink *ChatInviteLink `json:"invite_link,omitempty"`
 
 	// Optional. Identifier of the join request query; for bots assigned to process join requests only.
 	// If present, then the bot must call SendChatJoinRequestWebApp or directly call
@@ -0,0 +1,101 @@
+package tgbotapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+var _ Sendable = AnswerShippingQueryConfig{}
+
+// ShippingOption represents one shipping option.
+// https://core.telegram.org/bots/api#shippingoption
+type ShippingOption struct {
+	ID     string         `json:"id"`     // Shipping option identifier
+	Title  string         `json:"title"`  // Option title
+	Prices []LabeledPrice `json:"prices"` // List of price portions
+}
+
+// NewShippingOption creates a shipping option with its price portions.
+func NewShippingOption(id, title string, prices ...LabeledPrice) ShippingOption {
+	return ShippingOption{ID: id, Title: title, Prices: prices}
+}
+
+// AnswerShippingQueryConfig replies to a shipping query, sent for invoices
+// with IsFlexible and NeedShippingAddress set.
+// https://core.telegram.org/bots/api#answershippingquery
+type AnswerShippingQueryConfig struct {
+	// Unique identifier for the query to be answered
+	ShippingQueryID string `json:"shipping_query_id"`
+
+	// Pass True if delivery to the specified address is possible and False if there are any problems (for example, if delivery to the specified address is not possible)
+	OK bool `json:"ok"`
+
+	// Required if ok is True. Available shipping options.
+	ShippingOptions []ShippingOption `json:"shipping_options,omitempty"`
+
+	// Required if ok is False. Error message in human readable form that explains why it is impossible to complete the order
+	// (e.g. "Sorry, delivery to your desired address is unavailable"). Telegram will display this message to the user.
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+func (c AnswerShippingQueryConfig) Values() (values url.Values, err error) {
+	if c.ShippingQueryID == "" {
+		err = errors.New("has no shipping query ID")
+		return
+	}
+	if c.OK {
+		if c.ErrorMessage != "" {
+			err = fmt.Errorf("has OK=true and error message: %s", c.ErrorMessage)
+			return
+		}
+		if len(c.ShippingOptions) == 0 {
+			err = errors.New("has OK=true and no shipping options")
+			return
+		}
+		for i, option := range c.ShippingOptions {
+			if option.ID == "" || option.Title == "" || len(option.Prices) == 0 {
+				err = fmt.Errorf("shipping option %d requires an ID, a title and prices", i)
+				return
+			}
+		}
+	} else if c.ErrorMessage == "" {
+		err = errors.New("has OK=false and no error message")
+		return
+	}
+	values = make(url.Values)
+	values.Set("shipping_query_id", c.ShippingQueryID)
+	values.Set("ok", strconv.FormatBool(c.OK))
+	if c.OK {
+		var b []byte
+		if b, err = encodeToJson(c.ShippingOptions); err != nil {
+			err = fmt.Errorf("failed to marshal shipping options as JSON: %w", err)
+			return
+		}
+		values.Set("shipping_options", string(b))
+	} else {
+		values.Set("error_message", c.ErrorMessage)
+	}
+	return
+}
+
+func (AnswerShippingQueryConfig) TelegramMethod() string {
+	return "answerShippingQuery"
+}
+
+func AnswerShippingQueryWithOK(shippingQueryID string, options ...ShippingOption) AnswerShippingQueryConfig {
+	return AnswerShippingQueryConfig{
+		ShippingQueryID: shippingQueryID,
+		OK:              true,
+		ShippingOptions: options,
+	}
+}
+
+func AnswerShippingQueryWithNotOK(shippingQueryID, errorMessage string) AnswerShippingQueryConfig {
+	return AnswerShippingQueryConfig{
+		ShippingQueryID: shippingQueryID,
+		OK:              false,
+		ErrorMessage:    errorMessage,
+	}
+}
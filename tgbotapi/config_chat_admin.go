@@ -0,0 +1,488 @@
+package tgbotapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ChatConfig identifies a chat for chat administration requests.
+// ChannelUsername, in the format @username, is used instead of ChatID when set.
+type ChatConfig struct {
+	ChatID          int64
+	ChannelUsername string
+}
+
+// values returns the chat_id of the chat.
+func (c ChatConfig) values() url.Values {
+	return url.Values{"chat_id": {fromChatID(c.ChatID, c.ChannelUsername)}}
+}
+
+// values returns the chat_id and user_id of the member.
+func (c ChatMemberConfig) values() url.Values {
+	return url.Values{
+		"chat_id": {fromChatID(c.ChatID, c.SuperGroupUsername)},
+		"user_id": {strconv.Itoa(c.UserID)},
+	}
+}
+
+// addUntilDate adds until_date, where 0 means forever.
+func addUntilDate(values url.Values, untilDate int) {
+	if untilDate != 0 {
+		values.Add("until_date", strconv.Itoa(untilDate))
+	}
+}
+
+// BanChatMemberConfig contains information about a banChatMember request.
+// The user can't return to the chat using invite links until unbanned.
+//
+// https://core.telegram.org/bots/api#banchatmember
+type BanChatMemberConfig struct {
+	ChatMemberConfig
+
+	// Date when the user will be unbanned, Unix time. If the user is banned
+	// for more than 366 days or less than 30 seconds from the current time
+	// they are considered to be banned forever. 0 bans forever.
+	UntilDate int
+
+	// Pass True to delete all messages from the chat for the user that is being removed
+	RevokeMessages bool
+}
+
+// Values returns url.Values representation of BanChatMemberConfig.
+func (c BanChatMemberConfig) Values() (url.Values, error) {
+	values := c.ChatMemberConfig.values()
+	addUntilDate(values, c.UntilDate)
+	if c.RevokeMessages {
+		values.Add("revoke_messages", "true")
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for banning a chat member.
+func (BanChatMemberConfig) TelegramMethod() string {
+	return "banChatMember"
+}
+
+// RestrictChatMemberConfig contains information about a restrictChatMember
+// request. Pass all permissions to lift restrictions from a user.
+//
+// https://core.telegram.org/bots/api#restrictchatmember
+type RestrictChatMemberConfig struct {
+	ChatMemberConfig
+
+	// New user permissions, required
+	Permissions *ChatPermissions
+
+	// Pass True if chat permissions are set independently. Otherwise, the
+	// can_send_other_messages and can_add_web_page_previews permissions will
+	// imply the can_send_messages, can_send_audios, can_send_documents,
+	// can_send_photos, can_send_videos, can_send_video_notes, and
+	// can_send_voice_notes permissions; the can_send_polls permission will
+	// imply the can_send_messages permission.
+	UseIndependentChatPermissions bool
+
+	// Date when restrictions will be lifted for the user, Unix time. 0 restricts forever.
+	UntilDate int
+}
+
+// Values returns url.Values representation of RestrictChatMemberConfig.
+func (c RestrictChatMemberConfig) Values() (url.Values, error) {
+	values := c.ChatMemberConfig.values()
+	if err := addChatPermissions(values, c.Permissions, c.UseIndependentChatPermissions); err != nil {
+		return values, err
+	}
+	addUntilDate(values, c.UntilDate)
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for restricting a chat member.
+func (RestrictChatMemberConfig) TelegramMethod() string {
+	return "restrictChatMember"
+}
+
+func addChatPermissions(values url.Values, permissions *ChatPermissions, independent bool) error {
+	if permissions == nil {
+		return errors.New("permissions are required")
+	}
+	data, err := json.Marshal(permissions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal permissions: %w", err)
+	}
+	values.Add("permissions", string(data))
+	if independent {
+		values.Add("use_independent_chat_permissions", "true")
+	}
+	return nil
+}
+
+// PromoteChatMemberConfig contains information about a promoteChatMember
+// request. The administrator rights that are not granted are revoked, so
+// pass no rights to demote a user.
+//
+// https://core.telegram.org/bots/api#promotechatmember
+type PromoteChatMemberConfig struct {
+	ChatMemberConfig
+	ChatAdministratorRights
+}
+
+// Values returns url.Values representation of PromoteChatMemberConfig.
+func (c PromoteChatMemberConfig) Values() (url.Values, error) {
+	values := c.ChatMemberConfig.values()
+	// The rights are sent as separate parameters named as their JSON fields.
+	data, err := json.Marshal(c.ChatAdministratorRights)
+	if err != nil {
+		return values, err
+	}
+	var rights map[string]bool
+	if err = json.Unmarshal(data, &rights); err != nil {
+		return values, err
+	}
+	for name, granted := range rights {
+		values.Add(name, strconv.FormatBool(granted))
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for promoting a chat member.
+func (PromoteChatMemberConfig) TelegramMethod() string {
+	return "promoteChatMember"
+}
+
+// SetChatAdministratorCustomTitleConfig contains information about a
+// setChatAdministratorCustomTitle request for an administrator promoted by the bot.
+//
+// https://core.telegram.org/bots/api#setchatadministratorcustomtitle
+type SetChatAdministratorCustomTitleConfig struct {
+	ChatMemberConfig
+
+	// New custom title for the administrator; 0-16 characters, emoji are not allowed
+	CustomTitle string
+}
+
+// Values returns url.Values representation of SetChatAdministratorCustomTitleConfig.
+func (c SetChatAdministratorCustomTitleConfig) Values() (url.Values, error) {
+	if n := len([]rune(c.CustomTitle)); n > 16 {
+		return nil, fmt.Errorf("custom title is %d characters long, the maximum is 16", n)
+	}
+	values := c.ChatMemberConfig.values()
+	values.Add("custom_title", c.CustomTitle)
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for setting an administrator's custom title.
+func (SetChatAdministratorCustomTitleConfig) TelegramMethod() string {
+	return "setChatAdministratorCustomTitle"
+}
+
+// ChatSenderChatConfig contains information about a banChatSenderChat or
+// unbanChatSenderChat request, see BotAPI.BanChatSenderChat.
+type ChatSenderChatConfig struct {
+	ChatConfig
+
+	// Unique identifier of the target sender chat
+	SenderChatID int64
+}
+
+func (c ChatSenderChatConfig) values() url.Values {
+	values := c.ChatConfig.values()
+	values.Add("sender_chat_id", strconv.FormatInt(c.SenderChatID, 10))
+	return values
+}
+
+// SetChatPermissionsConfig contains information about a setChatPermissions request.
+//
+// https://core.telegram.org/bots/api#setchatpermissions
+type SetChatPermissionsConfig struct {
+	ChatConfig
+
+	// New default chat permissions, required
+	Permissions *ChatPermissions
+
+	// Pass True if chat permissions are set independently,
+	// see RestrictChatMemberConfig.UseIndependentChatPermissions.
+	UseIndependentChatPermissions bool
+}
+
+// Values returns url.Values representation of SetChatPermissionsConfig.
+func (c SetChatPermissionsConfig) Values() (url.Values, error) {
+	values := c.ChatConfig.values()
+	if err := addChatPermissions(values, c.Permissions, c.UseIndependentChatPermissions); err != nil {
+		return values, err
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for setting chat permissions.
+func (SetChatPermissionsConfig) TelegramMethod() string {
+	return "setChatPermissions"
+}
+
+// SetChatPhotoConfig contains information about a setChatPhoto request.
+// Photos can't be changed for private chats.
+//
+// https://core.telegram.org/bots/api#setchatphoto
+type SetChatPhotoConfig struct {
+	ChatConfig
+
+	// New chat photo, it must be uploaded: NewInputFilePath, NewInputFileBytes or NewInputFileReader
+	Photo InputFile
+}
+
+// Values returns an error, as the photo is uploaded in a multipart request;
+// set it with BotAPI.SetChatPhoto.
+func (c SetChatPhotoConfig) Values() (url.Values, error) {
+	return nil, errors.New("chat photo must be uploaded in a multipart request, use BotAPI.SetChatPhoto")
+}
+
+// TelegramMethod returns Telegram API method name for setting a chat photo.
+func (SetChatPhotoConfig) TelegramMethod() string {
+	return "setChatPhoto"
+}
+
+// multipart returns the setChatPhoto request uploading the photo.
+func (c SetChatPhotoConfig) multipart() (*multipartRequest, error) {
+	if c.Photo.err == nil && !c.Photo.NeedsUpload() {
+		return nil, errors.New("chat photo must be uploaded, file IDs and URLs are not supported")
+	}
+	r := newMultipartRequest(paramsFromValues(c.ChatConfig.values()))
+	if err := r.addFile("photo", c.Photo); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SetChatTitleConfig contains information about a setChatTitle request.
+//
+// https://core.telegram.org/bots/api#setchattitle
+type SetChatTitleConfig struct {
+	ChatConfig
+
+	// New chat title, 1-128 characters
+	Title string
+}
+
+// Values returns url.Values representation of SetChatTitleConfig.
+func (c SetChatTitleConfig) Values() (url.Values, error) {
+	if n := len([]rune(c.Title)); n == 0 || n > 128 {
+		return nil, fmt.Errorf("chat title must be 1-128 characters long, got %d", n)
+	}
+	values := c.ChatConfig.values()
+	values.Add("title", c.Title)
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for setting a chat title.
+func (SetChatTitleConfig) TelegramMethod() string {
+	return "setChatTitle"
+}
+
+// SetChatDescriptionConfig contains information about a setChatDescription request.
+//
+// https://core.telegram.org/bots/api#setchatdescription
+type SetChatDescriptionConfig struct {
+	ChatConfig
+
+	// New chat description, 0-255 characters
+	Description string
+}
+
+// Values returns url.Values representation of SetChatDescriptionConfig.
+func (c SetChatDescriptionConfig) Values() (url.Values, error) {
+	if n := len([]rune(c.Description)); n > 255 {
+		return nil, fmt.Errorf("chat description is %d characters long, the maximum is 255", n)
+	}
+	values := c.ChatConfig.values()
+	values.Add("description", c.Description)
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for setting a chat description.
+func (SetChatDescriptionConfig) TelegramMethod() string {
+	return "setChatDescription"
+}
+
+var (
+	_ Sendable          = BanChatMemberConfig{}
+	_ Sendable          = RestrictChatMemberConfig{}
+	_ Sendable          = PromoteChatMemberConfig{}
+	_ Sendable          = SetChatAdministratorCustomTitleConfig{}
+	_ Sendable          = SetChatPermissionsConfig{}
+	_ multipartSendable = SetChatPhotoConfig{}
+	_ Sendable          = SetChatTitleConfig{}
+	_ Sendable          = SetChatDescriptionConfig{}
+)
+
+// BanChatMember bans a user in a group, a supergroup or a channel. The bot
+// must be an administrator with the can_restrict_members right.
+func (bot *BotAPI) BanChatMember(config BanChatMemberConfig) (APIResponse, error) {
+	return bot.BanChatMemberContext(context.Background(), config)
+}
+
+// BanChatMemberContext is BanChatMember using ctx for the HTTP call.
+func (bot *BotAPI) BanChatMemberContext(ctx context.Context, config BanChatMemberConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// RestrictChatMember restricts a user in a supergroup. The bot must be an
+// administrator with the can_restrict_members right.
+func (bot *BotAPI) RestrictChatMember(config RestrictChatMemberConfig) (APIResponse, error) {
+	return bot.RestrictChatMemberContext(context.Background(), config)
+}
+
+// RestrictChatMemberContext is RestrictChatMember using ctx for the HTTP call.
+func (bot *BotAPI) RestrictChatMemberContext(ctx context.Context, config RestrictChatMemberConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// PromoteChatMember promotes or demotes a user in a supergroup or a channel.
+// The bot must be an administrator with the rights it grants.
+func (bot *BotAPI) PromoteChatMember(config PromoteChatMemberConfig) (APIResponse, error) {
+	return bot.PromoteChatMemberContext(context.Background(), config)
+}
+
+// PromoteChatMemberContext is PromoteChatMember using ctx for the HTTP call.
+func (bot *BotAPI) PromoteChatMemberContext(ctx context.Context, config PromoteChatMemberConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// SetChatAdministratorCustomTitle sets a custom title for an administrator
+// in a supergroup promoted by the bot.
+func (bot *BotAPI) SetChatAdministratorCustomTitle(config SetChatAdministratorCustomTitleConfig) (APIResponse, error) {
+	return bot.SetChatAdministratorCustomTitleContext(context.Background(), config)
+}
+
+// SetChatAdministratorCustomTitleContext is SetChatAdministratorCustomTitle using ctx for the HTTP call.
+func (bot *BotAPI) SetChatAdministratorCustomTitleContext(ctx context.Context, config SetChatAdministratorCustomTitleConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// BanChatSenderChat bans a channel chat in a supergroup or a channel. Until
+// the chat is unbanned, its owner can't send messages on behalf of any of
+// their channels. The bot must be an administrator with the
+// can_restrict_members right.
+//
+// https://core.telegram.org/bots/api#banchatsenderchat
+func (bot *BotAPI) BanChatSenderChat(config ChatSenderChatConfig) (APIResponse, error) {
+	return bot.BanChatSenderChatContext(context.Background(), config)
+}
+
+// BanChatSenderChatContext is BanChatSenderChat using ctx for the HTTP call.
+func (bot *BotAPI) BanChatSenderChatContext(ctx context.Context, config ChatSenderChatConfig) (APIResponse, error) {
+	return bot.MakeRequestContext(ctx, "banChatSenderChat", config.values())
+}
+
+// UnbanChatSenderChat unbans a previously banned channel chat in a
+// supergroup or a channel. The bot must be an administrator with the
+// can_restrict_members right.
+//
+// https://core.telegram.org/bots/api#unbanchatsenderchat
+func (bot *BotAPI) UnbanChatSenderChat(config ChatSenderChatConfig) (APIResponse, error) {
+	return bot.UnbanChatSenderChatContext(context.Background(), config)
+}
+
+// UnbanChatSenderChatContext is UnbanChatSenderChat using ctx for the HTTP call.
+func (bot *BotAPI) UnbanChatSenderChatContext(ctx context.Context, config ChatSenderChatConfig) (APIResponse, error) {
+	return bot.MakeRequestContext(ctx, "unbanChatSenderChat", config.values())
+}
+
+// SetChatPermissions sets default chat permissions for all members. The bot
+// must be an administrator with the can_restrict_members right.
+func (bot *BotAPI) SetChatPermissions(config SetChatPermissionsConfig) (APIResponse, error) {
+	return bot.SetChatPermissionsContext(context.Background(), config)
+}
+
+// SetChatPermissionsContext is SetChatPermissions using ctx for the HTTP call.
+func (bot *BotAPI) SetChatPermissionsContext(ctx context.Context, config SetChatPermissionsConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// SetChatPhoto uploads a new chat photo. The bot must be an administrator
+// with the can_change_info right.
+func (bot *BotAPI) SetChatPhoto(config SetChatPhotoConfig) (APIResponse, error) {
+	return bot.SetChatPhotoContext(context.Background(), config)
+}
+
+// SetChatPhotoContext is SetChatPhoto using ctx for the HTTP call.
+func (bot *BotAPI) SetChatPhotoContext(ctx context.Context, config SetChatPhotoConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// DeleteChatPhoto deletes a chat photo. The bot must be an administrator
+// with the can_change_info right.
+//
+// https://core.telegram.org/bots/api#deletechatphoto
+func (bot *BotAPI) DeleteChatPhoto(config ChatConfig) (APIResponse, error) {
+	return bot.DeleteChatPhotoContext(context.Background(), config)
+}
+
+// DeleteChatPhotoContext is DeleteChatPhoto using ctx for the HTTP call.
+func (bot *BotAPI) DeleteChatPhotoContext(ctx context.Context, config ChatConfig) (APIResponse, error) {
+	return bot.MakeRequestContext(ctx, "deleteChatPhoto", config.values())
+}
+
+// SetChatTitle changes the title of a chat. The bot must be an
+// administrator with the can_change_info right.
+func (bot *BotAPI) SetChatTitle(config SetChatTitleConfig) (APIResponse, error) {
+	return bot.SetChatTitleContext(context.Background(), config)
+}
+
+// SetChatTitleContext is SetChatTitle using ctx for the HTTP call.
+func (bot *BotAPI) SetChatTitleContext(ctx context.Context, config SetChatTitleConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// SetChatDescription changes the description of a group, a supergroup or a
+// channel. The bot must be an administrator with the can_change_info right.
+func (bot *BotAPI) SetChatDescription(config SetChatDescriptionConfig) (APIResponse, error) {
+	return bot.SetChatDescriptionContext(context.Background(), config)
+}
+
+// SetChatDescriptionContext is SetChatDescription using ctx for the HTTP call.
+func (bot *BotAPI) SetChatDescriptionContext(ctx context.Context, config SetChatDescriptionConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// GetChatMember returns information about a member of a chat.
+//
+// https://core.telegram.org/bots/api#getchatmember
+func (bot *BotAPI) GetChatMember(config ChatMemberConfig) (ChatMember, error) {
+	return bot.GetChatMemberContext(context.Background(), config)
+}
+
+// GetChatMemberContext is GetChatMember using ctx for the HTTP call.
+func (bot *BotAPI) GetChatMemberContext(ctx context.Context, config ChatMemberConfig) (member ChatMember, err error) {
+	resp, err := bot.MakeRequestContext(ctx, "getChatMember", config.values())
+	if err != nil {
+		return nil, err
+	}
+	if member, err = unmarshalChatMember(resp.Result); err != nil {
+		return nil, fmt.Errorf("failed to decode Telegram API response for method %q: %w", "getChatMember", err)
+	}
+	if member == nil {
+		return nil, fmt.Errorf("unsupported chat member in Telegram API response for method %q: %s", "getChatMember", resp.Result)
+	}
+	return member, nil
+}
+
+// GetChatMemberCount returns the number of members in a chat.
+//
+// https://core.telegram.org/bots/api#getchatmembercount
+func (bot *BotAPI) GetChatMemberCount(config ChatConfig) (int, error) {
+	return bot.GetChatMemberCountContext(context.Background(), config)
+}
+
+// GetChatMemberCountContext is GetChatMemberCount using ctx for the HTTP call.
+func (bot *BotAPI) GetChatMemberCountContext(ctx context.Context, config ChatConfig) (count int, err error) {
+	resp, err := bot.MakeRequestContext(ctx, "getChatMemberCount", config.values())
+	if err != nil {
+		return count, err
+	}
+	if err = json.Unmarshal(resp.Result, &count); err != nil {
+		return count, fmt.Errorf("failed to decode Telegram API response for method %q: %w", "getChatMemberCount", err)
+	}
+	return count, nil
+}
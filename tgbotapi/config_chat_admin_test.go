@@ -0,0 +1,120 @@
+package tgbotapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestChatAdminConfigs_Values(t *testing.T) {
+	member := ChatMemberConfig{SuperGroupUsername: "@group", UserID: 7}
+	for _, tt := range []struct {
+		name   string
+		config Sendable
+		want   map[string]string
+	}{
+		{
+			name:   "ban",
+			config: BanChatMemberConfig{ChatMemberConfig: member, UntilDate: 1700000000, RevokeMessages: true},
+			want:   map[string]string{"chat_id": "@group", "user_id": "7", "until_date": "1700000000", "revoke_messages": "true"},
+		},
+		{
+			name: "restrict",
+			config: RestrictChatMemberConfig{
+				ChatMemberConfig:              member,
+				Permissions:                   &ChatPermissions{CanSendMessages: true},
+				UseIndependentChatPermissions: true,
+			},
+			want: map[string]string{"permissions": `{"can_send_messages":true}`, "use_independent_chat_permissions": "true", "until_date": ""},
+		},
+		{
+			name:   "promote",
+			config: PromoteChatMemberConfig{ChatMemberConfig: member, ChatAdministratorRights: ChatAdministratorRights{CanPinMessages: true, CanInviteUsers: true}},
+			want:   map[string]string{"can_pin_messages": "true", "can_invite_users": "true", "can_promote_members": ""},
+		},
+		{
+			name:   "custom_title",
+			config: SetChatAdministratorCustomTitleConfig{ChatMemberConfig: member, CustomTitle: "Moderator"},
+			want:   map[string]string{"custom_title": "Moderator"},
+		},
+		{
+			name:   "permissions",
+			config: SetChatPermissionsConfig{ChatConfig: ChatConfig{ChatID: -100}, Permissions: &ChatPermissions{}},
+			want:   map[string]string{"chat_id": "-100", "permissions": "{}"},
+		},
+		{
+			name:   "title",
+			config: SetChatTitleConfig{ChatConfig: ChatConfig{ChatID: -100}, Title: "News"},
+			want:   map[string]string{"title": "News"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := tt.config.Values()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for key, want := range tt.want {
+				if got := values.Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestChatAdminConfigs_Validation(t *testing.T) {
+	for name, config := range map[string]Sendable{
+		"restrict_without_permissions": RestrictChatMemberConfig{ChatMemberConfig: ChatMemberConfig{ChatID: 1, UserID: 2}},
+		"long_custom_title":            SetChatAdministratorCustomTitleConfig{CustomTitle: "a very long custom title"},
+		"empty_title":                  SetChatTitleConfig{ChatConfig: ChatConfig{ChatID: 1}},
+		"photo_values":                 SetChatPhotoConfig{ChatConfig: ChatConfig{ChatID: 1}, Photo: NewInputFileBytes("a.jpg", []byte("a"))},
+	} {
+		if _, err := config.Values(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := (SetChatPhotoConfig{Photo: NewInputFileID("AgAD")}).multipart(); err == nil {
+		t.Error("expected an error for a chat photo by file_id")
+	}
+}
+
+func TestSetChatPhoto_Uploads(t *testing.T) {
+	bot := multipartBot(t, func(r *http.Request) {
+		if got := formFile(t, r, "photo"); got != "jpeg" {
+			t.Errorf("photo = %q", got)
+		}
+		if got := r.FormValue("chat_id"); got != "@channel" {
+			t.Errorf("chat_id = %q", got)
+		}
+	})
+	config := SetChatPhotoConfig{ChatConfig: ChatConfig{ChannelUsername: "@channel"}, Photo: NewInputFileBytes("photo.jpg", []byte("jpeg"))}
+	if _, err := bot.SetChatPhoto(config); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetChatMember(t *testing.T) {
+	bot := testBotWithResponse("123456:TOKEN", http.StatusOK,
+		`{"ok":true,"result":{"status":"restricted","user":{"id":7,"first_name":"A"},"until_date":1700000000,"can_send_messages":false}}`)
+	member, err := bot.GetChatMember(ChatMemberConfig{ChatID: -100, UserID: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restricted, ok := member.(*ChatMemberRestricted); !ok || restricted.User.ID != 7 || restricted.UntilDate != 1700000000 {
+		t.Errorf("member = %+v", member)
+	}
+
+	bot = testBotWithResponse("123456:TOKEN", http.StatusOK,
+		`{"ok":true,"result":[{"status":"creator","user":{"id":1}},{"status":"administrator","user":{"id":2},"can_pin_messages":true}]}`)
+	admins, err := bot.GetChatAdministrators("-100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(admins) != 2 || !admins[0].IsAdmin() || !admins[1].AdministratorRights().CanPinMessages {
+		t.Errorf("admins = %+v", admins)
+	}
+
+	bot = testBotWithResponse("123456:TOKEN", http.StatusOK, `{"ok":true,"result":42}`)
+	if count, err := bot.GetChatMemberCount(ChatConfig{ChatID: -100}); err != nil || count != 42 {
+		t.Errorf("count = %d, err = %v", count, err)
+	}
+}
@@ -0,0 +1,360 @@
+package tgbotapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// MaxMessagesPerBatch is the maximum number of message identifiers accepted by
+// copyMessages, forwardMessages and deleteMessages in one call.
+const MaxMessagesPerBatch = 100
+
+// ErrNoMessageIDs is returned for a batch request without message identifiers.
+var ErrNoMessageIDs = errors.New("no message identifiers")
+
+// MessageID is a unique message identifier returned by copyMessage and copyMessages.
+//
+// https://core.telegram.org/bots/api#messageid
+type MessageID struct {
+	MessageID int `json:"message_id"`
+}
+
+// CopyMessageConfig contains information about a copyMessage request.
+// The copy has no link to the original message.
+//
+// https://core.telegram.org/bots/api#copymessage
+type CopyMessageConfig struct {
+	BaseChat
+
+	// Unique identifier for the chat where the original message was sent, or
+	// FromChannelUsername in the format @channelusername
+	FromChatID          int64  `json:"from_chat_id"`
+	FromChannelUsername string `json:"-"`
+
+	// Message identifier in the chat specified in FromChatID
+	MessageID int `json:"message_id"`
+
+	// Optional. New start timestamp for the copied video in the message
+	VideoStartTimestamp int `json:"video_start_timestamp,omitempty"`
+
+	// Optional. New caption for media, 0-1024 characters after entities parsing. If not specified, the original caption is kept
+	Caption               string          `json:"caption,omitempty"`
+	ParseMode             string          `json:"parse_mode,omitempty"`
+	CaptionEntities       []MessageEntity `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool            `json:"show_caption_above_media,omitempty"`
+}
+
+// Values returns url.Values representation of CopyMessageConfig.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v CopyMessageConfig) Values() (url.Values, error) {
+	values, err := v.BaseChat.Values()
+	if err != nil {
+		return values, err
+	}
+	values.Add("from_chat_id", fromChatID(v.FromChatID, v.FromChannelUsername))
+	values.Add("message_id", strconv.Itoa(v.MessageID))
+	if v.VideoStartTimestamp != 0 {
+		values.Add("video_start_timestamp", strconv.Itoa(v.VideoStartTimestamp))
+	}
+	if v.Caption != "" {
+		values.Add("caption", v.Caption)
+	}
+	if v.ParseMode != "" {
+		values.Add("parse_mode", v.ParseMode)
+	}
+	if len(v.CaptionEntities) > 0 {
+		data, err := encodeToJson(v.CaptionEntities)
+		if err != nil {
+			return values, fmt.Errorf("failed to marshal caption_entities: %w", err)
+		}
+		values.Add("caption_entities", string(data))
+	}
+	if v.ShowCaptionAboveMedia {
+		values.Add("show_caption_above_media", "true")
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for copying a message.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v CopyMessageConfig) TelegramMethod() string {
+	return "copyMessage"
+}
+
+// ForwardMessagesConfig contains information about a forwardMessages request.
+// Album grouping is kept for forwarded messages.
+//
+// MessageIDs are sent sorted and without duplicates, as the API requires;
+// use BotAPI.ForwardMessagesInBatches for more than MaxMessagesPerBatch.
+//
+// https://core.telegram.org/bots/api#forwardmessages
+type ForwardMessagesConfig struct {
+	ChatID                int64  `json:"chat_id"`
+	ChannelUsername       string `json:"-"`
+	MessageThreadID       int64  `json:"message_thread_id,omitempty"`
+	DirectMessagesTopicID int64  `json:"direct_messages_topic_id,omitempty"`
+	FromChatID            int64  `json:"from_chat_id"`
+	FromChannelUsername   string `json:"-"`
+	MessageIDs            []int  `json:"message_ids"`
+	DisableNotification   bool   `json:"disable_notification,omitempty"`
+	ProtectContent        bool   `json:"protect_content,omitempty"`
+}
+
+// Values returns url.Values representation of ForwardMessagesConfig.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v ForwardMessagesConfig) Values() (url.Values, error) {
+	return messagesBatchValues(v.ChatID, v.ChannelUsername, v.MessageThreadID, v.DirectMessagesTopicID,
+		fromChatID(v.FromChatID, v.FromChannelUsername), v.MessageIDs, v.DisableNotification, v.ProtectContent)
+}
+
+// TelegramMethod returns Telegram API method name for forwarding messages.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v ForwardMessagesConfig) TelegramMethod() string {
+	return "forwardMessages"
+}
+
+// CopyMessagesConfig contains information about a copyMessages request.
+// Album grouping is kept for copied messages.
+//
+// MessageIDs are sent sorted and without duplicates, as the API requires;
+// use BotAPI.CopyMessagesInBatches for more than MaxMessagesPerBatch.
+//
+// https://core.telegram.org/bots/api#copymessages
+type CopyMessagesConfig struct {
+	ChatID                int64  `json:"chat_id"`
+	ChannelUsername       string `json:"-"`
+	MessageThreadID       int64  `json:"message_thread_id,omitempty"`
+	DirectMessagesTopicID int64  `json:"direct_messages_topic_id,omitempty"`
+	FromChatID            int64  `json:"from_chat_id"`
+	FromChannelUsername   string `json:"-"`
+	MessageIDs            []int  `json:"message_ids"`
+	DisableNotification   bool   `json:"disable_notification,omitempty"`
+	ProtectContent        bool   `json:"protect_content,omitempty"`
+
+	// Pass True to copy the messages without their captions
+	RemoveCaption bool `json:"remove_caption,omitempty"`
+}
+
+// Values returns url.Values representation of CopyMessagesConfig.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v CopyMessagesConfig) Values() (url.Values, error) {
+	values, err := messagesBatchValues(v.ChatID, v.ChannelUsername, v.MessageThreadID, v.DirectMessagesTopicID,
+		fromChatID(v.FromChatID, v.FromChannelUsername), v.MessageIDs, v.DisableNotification, v.ProtectContent)
+	if err != nil {
+		return values, err
+	}
+	if v.RemoveCaption {
+		values.Add("remove_caption", "true")
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for copying messages.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v CopyMessagesConfig) TelegramMethod() string {
+	return "copyMessages"
+}
+
+// DeleteMessagesConfig contains information about a deleteMessages request.
+// Messages that can't be found are skipped.
+//
+// https://core.telegram.org/bots/api#deletemessages
+type DeleteMessagesConfig struct {
+	ChatID          int64  `json:"chat_id"`
+	ChannelUsername string `json:"-"`
+	MessageIDs      []int  `json:"message_ids"`
+}
+
+// Values returns url.Values representation of DeleteMessagesConfig.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v DeleteMessagesConfig) Values() (url.Values, error) {
+	values := url.Values{}
+	values.Add("chat_id", fromChatID(v.ChatID, v.ChannelUsername))
+	if err := addMessageIDs(values, v.MessageIDs); err != nil {
+		return values, err
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for deleting messages.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v DeleteMessagesConfig) TelegramMethod() string {
+	return "deleteMessages"
+}
+
+var (
+	_ Sendable = CopyMessageConfig{}
+	_ Sendable = ForwardMessagesConfig{}
+	_ Sendable = CopyMessagesConfig{}
+	_ Sendable = DeleteMessagesConfig{}
+)
+
+// SplitMessageIDs sorts messageIDs, drops duplicates and splits them into
+// batches of at most MaxMessagesPerBatch identifiers in increasing order.
+// messageIDs is not modified.
+func SplitMessageIDs(messageIDs []int) [][]int {
+	ids := normalizeMessageIDs(messageIDs)
+	batches := make([][]int, 0, (len(ids)+MaxMessagesPerBatch-1)/MaxMessagesPerBatch)
+	for batch := range slices.Chunk(ids, MaxMessagesPerBatch) {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func normalizeMessageIDs(messageIDs []int) []int {
+	ids := slices.Clone(messageIDs)
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+func fromChatID(chatID int64, channelUsername string) string {
+	if channelUsername != "" {
+		return channelUsername
+	}
+	return strconv.FormatInt(chatID, 10)
+}
+
+func addMessageIDs(values url.Values, messageIDs []int) error {
+	ids := normalizeMessageIDs(messageIDs)
+	switch {
+	case len(ids) == 0:
+		return ErrNoMessageIDs
+	case len(ids) > MaxMessagesPerBatch:
+		return fmt.Errorf("too many message identifiers: %d, the maximum is %d", len(ids), MaxMessagesPerBatch)
+	}
+	data, err := encodeToJson(ids)
+	if err != nil {
+		return err
+	}
+	values.Add("message_ids", string(data))
+	return nil
+}
+
+func messagesBatchValues(
+	chatID int64, channelUsername string, messageThreadID, directMessagesTopicID int64,
+	from string, messageIDs []int, disableNotification, protectContent bool,
+) (url.Values, error) {
+	values := url.Values{}
+	values.Add("chat_id", fromChatID(chatID, channelUsername))
+	if messageThreadID != 0 {
+		values.Add("message_thread_id", strconv.FormatInt(messageThreadID, 10))
+	}
+	if directMessagesTopicID != 0 {
+		values.Add("direct_messages_topic_id", strconv.FormatInt(directMessagesTopicID, 10))
+	}
+	values.Add("from_chat_id", from)
+	if err := addMessageIDs(values, messageIDs); err != nil {
+		return values, err
+	}
+	if disableNotification {
+		values.Add("disable_notification", "true")
+	}
+	if protectContent {
+		values.Add("protect_content", "true")
+	}
+	return values, nil
+}
+
+// CopyMessage copies a message and returns the identifier of the copy.
+// Unlike forwarding, the copy has no link to the original message.
+func (bot *BotAPI) CopyMessage(config CopyMessageConfig) (MessageID, error) {
+	return bot.CopyMessageContext(context.Background(), config)
+}
+
+// CopyMessageContext is CopyMessage using ctx for the HTTP call.
+func (bot *BotAPI) CopyMessageContext(ctx context.Context, config CopyMessageConfig) (messageID MessageID, err error) {
+	err = bot.SendCustomMessage(ctx, config, &messageID)
+	return
+}
+
+// ForwardMessages forwards up to MaxMessagesPerBatch messages and returns the
+// identifiers of the sent messages in the order of the originals.
+func (bot *BotAPI) ForwardMessages(config ForwardMessagesConfig) ([]MessageID, error) {
+	return bot.ForwardMessagesContext(context.Background(), config)
+}
+
+// ForwardMessagesContext is ForwardMessages using ctx for the HTTP call.
+func (bot *BotAPI) ForwardMessagesContext(ctx context.Context, config ForwardMessagesConfig) (messageIDs []MessageID, err error) {
+	err = bot.SendCustomMessage(ctx, config, &messageIDs)
+	return
+}
+
+// CopyMessages copies up to MaxMessagesPerBatch messages and returns the
+// identifiers of the copies in the order of the originals.
+func (bot *BotAPI) CopyMessages(config CopyMessagesConfig) ([]MessageID, error) {
+	return bot.CopyMessagesContext(context.Background(), config)
+}
+
+// CopyMessagesContext is CopyMessages using ctx for the HTTP call.
+func (bot *BotAPI) CopyMessagesContext(ctx context.Context, config CopyMessagesConfig) (messageIDs []MessageID, err error) {
+	err = bot.SendCustomMessage(ctx, config, &messageIDs)
+	return
+}
+
+// DeleteMessages deletes up to MaxMessagesPerBatch messages at once.
+func (bot *BotAPI) DeleteMessages(config DeleteMessagesConfig) (APIResponse, error) {
+	return bot.DeleteMessagesContext(context.Background(), config)
+}
+
+// DeleteMessagesContext is DeleteMessages using ctx for the HTTP call.
+func (bot *BotAPI) DeleteMessagesContext(ctx context.Context, config DeleteMessagesConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// ForwardMessagesInBatches forwards any number of messages, calling
+// forwardMessages for each batch of SplitMessageIDs in turn, so the sent
+// messages keep the order of the originals. On error it returns the
+// identifiers of the messages sent by the previous batches.
+func (bot *BotAPI) ForwardMessagesInBatches(ctx context.Context, config ForwardMessagesConfig) (sent []MessageID, err error) {
+	for i, batch := range SplitMessageIDs(config.MessageIDs) {
+		config.MessageIDs = batch
+		var messageIDs []MessageID
+		if messageIDs, err = bot.ForwardMessagesContext(ctx, config); err != nil {
+			return sent, fmt.Errorf("batch %d: %w", i, err)
+		}
+		sent = append(sent, messageIDs...)
+	}
+	return sent, nil
+}
+
+// CopyMessagesInBatches copies any number of messages, calling copyMessages
+// for each batch of SplitMessageIDs in turn, so the copies keep the order of
+// the originals. On error it returns the identifiers of the copies made by
+// the previous batches.
+func (bot *BotAPI) CopyMessagesInBatches(ctx context.Context, config CopyMessagesConfig) (sent []MessageID, err error) {
+	for i, batch := range SplitMessageIDs(config.MessageIDs) {
+		config.MessageIDs = batch
+		var messageIDs []MessageID
+		if messageIDs, err = bot.CopyMessagesContext(ctx, config); err != nil {
+			return sent, fmt.Errorf("batch %d: %w", i, err)
+		}
+		sent = append(sent, messageIDs...)
+	}
+	return sent, nil
+}
+
+// DeleteMessagesInBatches deletes any number of messages, calling
+// deleteMessages for each batch of SplitMessageIDs in turn. On error it
+// returns the number of batches deleted before it.
+func (bot *BotAPI) DeleteMessagesInBatches(ctx context.Context, config DeleteMessagesConfig) (deletedBatches int, err error) {
+	batches := SplitMessageIDs(config.MessageIDs)
+	for i, batch := range batches {
+		config.MessageIDs = batch
+		if _, err = bot.DeleteMessagesContext(ctx, config); err != nil {
+			return i, fmt.Errorf("batch %d: %w", i, err)
+		}
+	}
+	return len(batches), nil
+}
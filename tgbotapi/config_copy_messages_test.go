@@ -0,0 +1,141 @@
+package tgbotapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCopyMessageConfig_Values(t *testing.T) {
+	config := CopyMessageConfig{
+		BaseChat:            BaseChat{ChatID: 1, ReplyMarkup: NewInlineKeyboardMarkup(NewInlineKeyboardRow(NewInlineKeyboardButtonData("A", "a")))},
+		FromChannelUsername: "@archive",
+		MessageID:           7,
+		Caption:             "<b>new</b>",
+		ParseMode:           ModeHTML,
+	}
+	values, err := config.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{
+		"chat_id":      "1",
+		"from_chat_id": "@archive",
+		"message_id":   "7",
+		"caption":      "<b>new</b>",
+		"parse_mode":   "HTML",
+	} {
+		if got := values.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	if values.Get("reply_markup") == "" {
+		t.Error("reply_markup is missing")
+	}
+}
+
+func TestMessagesBatchConfigs_Values(t *testing.T) {
+	values, err := CopyMessagesConfig{ChatID: 1, FromChatID: 2, MessageIDs: []int{5, 3, 5, 4}, RemoveCaption: true}.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := values.Get("message_ids"); got != "[3,4,5]\n" {
+		t.Errorf("message_ids = %s, want sorted without duplicates", got)
+	}
+	if values.Get("remove_caption") != "true" || values.Get("from_chat_id") != "2" {
+		t.Errorf("values = %v", values)
+	}
+
+	if _, err = (DeleteMessagesConfig{ChatID: 1}).Values(); !errors.Is(err, ErrNoMessageIDs) {
+		t.Errorf("empty: err = %v, want ErrNoMessageIDs", err)
+	}
+	tooMany := make([]int, MaxMessagesPerBatch+1)
+	for i := range tooMany {
+		tooMany[i] = i + 1
+	}
+	if _, err = (ForwardMessagesConfig{ChatID: 1, FromChatID: 2, MessageIDs: tooMany}).Values(); err == nil {
+		t.Error("expected an error for too many message identifiers")
+	}
+}
+
+func TestSplitMessageIDs(t *testing.T) {
+	ids := make([]int, 0, 250)
+	for i := 250; i > 0; i-- {
+		ids = append(ids, i)
+	}
+	batches := SplitMessageIDs(append(ids, 1))
+	if len(batches) != 3 || len(batches[0]) != 100 || len(batches[2]) != 50 {
+		t.Fatalf("batch sizes = %d", len(batches))
+	}
+	if batches[0][0] != 1 || batches[1][0] != 101 || batches[2][49] != 250 {
+		t.Errorf("batches are not in increasing order: %v", batches)
+	}
+	if ids[0] != 250 {
+		t.Error("input was modified")
+	}
+	if got := SplitMessageIDs(nil); len(got) != 0 {
+		t.Errorf("SplitMessageIDs(nil) = %v", got)
+	}
+}
+
+func TestCopyMessage(t *testing.T) {
+	bot := testBotWithResponse("123456:TOKEN", http.StatusOK, `{"ok":true,"result":{"message_id":42}}`)
+	messageID, err := bot.CopyMessage(*NewCopyMessage(1, 2, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if messageID.MessageID != 42 {
+		t.Errorf("message_id = %d, want 42", messageID.MessageID)
+	}
+}
+
+func TestCopyMessagesInBatches(t *testing.T) {
+	var batches [][]int
+	next := 1000
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			var ids []int
+			if err := json.Unmarshal([]byte(r.PostForm.Get("message_ids")), &ids); err != nil {
+				t.Fatal(err)
+			}
+			batches = append(batches, ids)
+			status, body := http.StatusOK, `{"ok":true,"result":[`
+			if len(batches) == 3 {
+				status, body = http.StatusBadRequest, `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`
+			} else {
+				for i := range ids {
+					if i > 0 {
+						body += ","
+					}
+					next++
+					body += `{"message_id":` + strconv.Itoa(next) + `}`
+				}
+				body += `]}`
+			}
+			return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+		}),
+	})
+
+	ids := make([]int, 250)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	sent, err := bot.CopyMessagesInBatches(context.Background(), CopyMessagesConfig{ChatID: 1, FromChatID: 2, MessageIDs: ids})
+	if err == nil {
+		t.Fatal("expected the error of the third batch")
+	}
+	if len(batches) != 3 || len(batches[0]) != 100 || batches[1][0] != 101 {
+		t.Errorf("batches = %d", len(batches))
+	}
+	if len(sent) != 200 || sent[0].MessageID != 1001 || sent[199].MessageID != 1200 {
+		t.Errorf("sent %d messages, want the 200 of the successful batches in order", len(sent))
+	}
+}
@@ -1,6 +1,7 @@
 package tgbotapi
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -134,8 +135,8 @@ func (EditEphemeralMessageTextConfig) TelegramMethod() string {
 var _ Sendable = EditEphemeralMessageTextConfig{}
 
 // EditEphemeralMessageMediaConfig allows you to modify the media of an ephemeral message (Bot API 10.2
-// Ephemeral Messages). A new file can't be uploaded; use a previously uploaded file via its file_id or
-// specify a URL.
+// Ephemeral Messages). Files to upload are sent in the same multipart request and referenced from the
+// media JSON as attach://<name>.
 //
 // https://core.telegram.org/bots/api#editephemeralmessagemedia
 type EditEphemeralMessageMediaConfig struct {
@@ -144,6 +145,12 @@ type EditEphemeralMessageMediaConfig struct {
 	// A JSON-serialized object for the new media content of the message. Must be one of
 	// *InputMediaAnimation, *InputMediaAudio, *InputMediaPhoto, or *InputMediaVideo.
 	Media any `json:"media"`
+
+	// Optional. The new file, its thumbnail and the cover of a video, set as the Media, Thumbnail and
+	// Cover fields of Media. Zero files leave the fields of Media unchanged.
+	File      InputFile `json:"-"`
+	Thumbnail InputFile `json:"-"`
+	Cover     InputFile `json:"-"`
 }
 
 // NewEditEphemeralMessageMedia constructs an ephemeral media edit.
@@ -154,25 +161,48 @@ func NewEditEphemeralMessageMedia(chatID, receiverUserID, ephemeralMessageID int
 	}
 }
 
-// Values returns URL values representation of EditEphemeralMessageMediaConfig
+// Values returns URL values representation of EditEphemeralMessageMediaConfig.
+// Media to upload is only supported by multipart.
 //
 //goland:noinspection GoMixedReceiverTypes
 func (v EditEphemeralMessageMediaConfig) Values() (url.Values, error) {
-	values, err := v.baseEphemeralMessageEdit.Values()
+	r, err := v.multipart()
 	if err != nil {
-		return values, err
+		return nil, err
 	}
-	if v.Media == nil {
-		return values, fmt.Errorf("media is required")
+	if len(r.parts) > 0 {
+		return nil, errors.New("ephemeral message media to upload must be sent as multipart")
 	}
+	return r.values(), nil
+}
 
-	data, err := encodeToJson(v.Media)
+// multipart returns the editEphemeralMessageMedia request with the files to upload.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v EditEphemeralMessageMediaConfig) multipart() (*multipartRequest, error) {
+	values, err := v.baseEphemeralMessageEdit.Values()
 	if err != nil {
-		return values, fmt.Errorf("failed to marshal media as JSON: %w", err)
+		return nil, err
 	}
-	values.Add("media", string(data))
+	if v.Media == nil {
+		return nil, fmt.Errorf("media is required")
+	}
+	r := newMultipartRequest(paramsFromValues(values))
 
-	return values, nil
+	media := v.Media
+	if !v.File.IsZero() || !v.Thumbnail.IsZero() || !v.Cover.IsZero() {
+		refs, err := MediaGroupItem{File: v.File, Thumbnail: v.Thumbnail, Cover: v.Cover}.attach(r)
+		if err != nil {
+			return nil, err
+		}
+		if media, _, err = withInputMediaRefs(media, refs); err != nil {
+			return nil, err
+		}
+	}
+	if err = r.addJSON("media", media); err != nil {
+		return nil, fmt.Errorf("failed to marshal media as JSON: %w", err)
+	}
+	return r, nil
 }
 
 //goland:noinspection GoMixedReceiverTypes
@@ -180,7 +210,7 @@ func (EditEphemeralMessageMediaConfig) TelegramMethod() string {
 	return "editEphemeralMessageMedia"
 }
 
-var _ Sendable = EditEphemeralMessageMediaConfig{}
+var _ multipartSendable = EditEphemeralMessageMediaConfig{}
 
 // EditEphemeralMessageCaptionConfig allows you to modify the caption of an ephemeral message (Bot API
 // 10.2 Ephemeral Messages).
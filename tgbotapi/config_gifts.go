@@ -0,0 +1,541 @@
+package tgbotapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Gifts represents a list of gifts.
+// https://core.telegram.org/bots/api#gifts
+type Gifts struct {
+	// The list of gifts
+	Gifts []Gift `json:"gifts"`
+}
+
+// SendGiftConfig contains information about a sendGift request. Exactly one
+// of UserID and ChatID or ChannelUsername must be set.
+//
+// https://core.telegram.org/bots/api#sendgift
+type SendGiftConfig struct {
+	// Unique identifier of the target user who will receive the gift
+	UserID int64
+
+	// Unique identifier for the chat or username of the channel (in the format @channelusername) that will receive the gift
+	ChatID          int64
+	ChannelUsername string
+
+	// Identifier of the gift; limited gifts can't be sent to channel chats
+	GiftID string
+
+	// Pass True to pay for the gift upgrade from the bot's balance, thereby making the upgrade free for the receiver
+	PayForUpgrade bool
+
+	// Text that will be shown along with the gift; 0-128 characters
+	Text string
+
+	// Mode for parsing entities in the text
+	TextParseMode string
+
+	// A list of special entities that appear in the gift text. It can be specified instead of text_parse_mode
+	TextEntities []MessageEntity
+}
+
+// NewSendGift returns a config sending the gift to a user.
+func NewSendGift(userID int64, giftID string) SendGiftConfig {
+	return SendGiftConfig{UserID: userID, GiftID: giftID}
+}
+
+// Values returns url.Values representation of SendGiftConfig.
+func (c SendGiftConfig) Values() (url.Values, error) {
+	hasChat := c.ChatID != 0 || c.ChannelUsername != ""
+	switch {
+	case c.UserID == 0 && !hasChat:
+		return nil, errors.New("user_id or chat_id is required")
+	case c.UserID != 0 && hasChat:
+		return nil, errors.New("only one of user_id and chat_id can be set")
+	case c.GiftID == "":
+		return nil, errors.New("gift_id is required")
+	}
+	values := url.Values{}
+	if c.UserID != 0 {
+		values.Add("user_id", strconv.FormatInt(c.UserID, 10))
+	} else {
+		values.Add("chat_id", fromChatID(c.ChatID, c.ChannelUsername))
+	}
+	values.Add("gift_id", c.GiftID)
+	if c.PayForUpgrade {
+		values.Add("pay_for_upgrade", "true")
+	}
+	if err := addGiftText(values, c.Text, c.TextParseMode, c.TextEntities); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for sending a gift.
+func (SendGiftConfig) TelegramMethod() string {
+	return "sendGift"
+}
+
+// addGiftText adds the text shown along with a gift, 0-128 characters.
+func addGiftText(values url.Values, text, parseMode string, entities []MessageEntity) error {
+	if n := len([]rune(text)); n > 128 {
+		return fmt.Errorf("gift text must be at most 128 characters long, got %d", n)
+	}
+	if text != "" {
+		values.Add("text", text)
+	}
+	if parseMode != "" {
+		values.Add("text_parse_mode", parseMode)
+	}
+	if len(entities) > 0 {
+		data, err := json.Marshal(entities)
+		if err != nil {
+			return err
+		}
+		values.Add("text_entities", string(data))
+	}
+	return nil
+}
+
+// premiumSubscriptionStarCounts maps the supported durations of a gifted
+// Telegram Premium subscription in months to their prices in Telegram Stars.
+var premiumSubscriptionStarCounts = map[int]int{3: 1000, 6: 1500, 12: 2500}
+
+// GiftPremiumSubscriptionConfig contains information about a
+// giftPremiumSubscription request.
+//
+// https://core.telegram.org/bots/api#giftpremiumsubscription
+type GiftPremiumSubscriptionConfig struct {
+	// Unique identifier of the target user who will receive a Telegram Premium subscription
+	UserID int64
+
+	// Number of months the Telegram Premium subscription will be active for the user; must be one of 3, 6, or 12
+	MonthCount int
+
+	// Number of Telegram Stars to pay for the Telegram Premium subscription; must be 1000 for 3 months, 1500 for 6 months, and 2500 for 12 months.
+	// If 0, the price for MonthCount is used.
+	StarCount int
+
+	// Text that will be shown along with the service message about the subscription; 0-128 characters
+	Text string
+
+	// Mode for parsing entities in the text
+	TextParseMode string
+
+	// A list of special entities that appear in the gift text. It can be specified instead of text_parse_mode
+	TextEntities []MessageEntity
+}
+
+// Values returns url.Values representation of GiftPremiumSubscriptionConfig.
+func (c GiftPremiumSubscriptionConfig) Values() (url.Values, error) {
+	if c.UserID == 0 {
+		return nil, errors.New("user_id is required")
+	}
+	starCount, ok := premiumSubscriptionStarCounts[c.MonthCount]
+	if !ok {
+		return nil, fmt.Errorf("month count must be 3, 6 or 12, got %d", c.MonthCount)
+	}
+	if c.StarCount != 0 && c.StarCount != starCount {
+		return nil, fmt.Errorf("star count for %d months must be %d, got %d", c.MonthCount, starCount, c.StarCount)
+	}
+	values := url.Values{}
+	values.Add("user_id", strconv.FormatInt(c.UserID, 10))
+	values.Add("month_count", strconv.Itoa(c.MonthCount))
+	values.Add("star_count", strconv.Itoa(starCount))
+	if err := addGiftText(values, c.Text, c.TextParseMode, c.TextEntities); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for gifting Telegram Premium.
+func (GiftPremiumSubscriptionConfig) TelegramMethod() string {
+	return "giftPremiumSubscription"
+}
+
+// OwnedGiftsFilter selects and pages the gifts returned by getUserGifts,
+// getChatGifts and getBusinessAccountGifts.
+type OwnedGiftsFilter struct {
+	// Pass True to exclude gifts that aren't saved to the profile page; for chats and business accounts only
+	ExcludeUnsaved bool
+
+	// Pass True to exclude gifts that are saved to the profile page; for chats and business accounts only
+	ExcludeSaved bool
+
+	// Pass True to exclude gifts that can be purchased an unlimited number of times
+	ExcludeUnlimited bool
+
+	// Pass True to exclude gifts that can be purchased a limited number of times and can be upgraded to unique
+	ExcludeLimitedUpgradable bool
+
+	// Pass True to exclude gifts that can be purchased a limited number of times and can't be upgraded to unique
+	ExcludeLimitedNonUpgradable bool
+
+	// Pass True to exclude unique gifts
+	ExcludeUnique bool
+
+	// Pass True to exclude gifts that were assigned from the TON blockchain and can't be resold or transferred in Telegram
+	ExcludeFromBlockchain bool
+
+	// Pass True to sort results by gift price instead of send date. Sorting is applied before pagination.
+	SortByPrice bool
+
+	// Offset of the first entry to return as received from the previous request; use an empty string to get the first chunk of results
+	Offset string
+
+	// The maximum number of gifts to be returned; 1-100. Defaults to 100
+	Limit int
+}
+
+// values adds the filter parameters, including the saved filters if withSaved.
+func (f OwnedGiftsFilter) values(values url.Values, withSaved bool) error {
+	if f.Limit < 0 || f.Limit > 100 {
+		return fmt.Errorf("limit must be between 1 and 100, got %d", f.Limit)
+	}
+	add := func(name string, value bool) {
+		if value {
+			values.Add(name, "true")
+		}
+	}
+	if withSaved {
+		add("exclude_unsaved", f.ExcludeUnsaved)
+		add("exclude_saved", f.ExcludeSaved)
+	}
+	add("exclude_unlimited", f.ExcludeUnlimited)
+	add("exclude_limited_upgradable", f.ExcludeLimitedUpgradable)
+	add("exclude_limited_non_upgradable", f.ExcludeLimitedNonUpgradable)
+	add("exclude_unique", f.ExcludeUnique)
+	add("exclude_from_blockchain", f.ExcludeFromBlockchain)
+	add("sort_by_price", f.SortByPrice)
+	if f.Offset != "" {
+		values.Add("offset", f.Offset)
+	}
+	if f.Limit > 0 {
+		values.Add("limit", strconv.Itoa(f.Limit))
+	}
+	return nil
+}
+
+// GetUserGiftsConfig contains information about a getUserGifts request.
+//
+// https://core.telegram.org/bots/api#getusergifts
+type GetUserGiftsConfig struct {
+	// Unique identifier of the user
+	UserID int64
+
+	OwnedGiftsFilter
+}
+
+// Values returns url.Values representation of GetUserGiftsConfig.
+func (c GetUserGiftsConfig) Values() (url.Values, error) {
+	if c.UserID == 0 {
+		return nil, errors.New("user_id is required")
+	}
+	values := url.Values{}
+	values.Add("user_id", strconv.FormatInt(c.UserID, 10))
+	if err := c.OwnedGiftsFilter.values(values, false); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for getting the gifts of a user.
+func (GetUserGiftsConfig) TelegramMethod() string {
+	return "getUserGifts"
+}
+
+// GetChatGiftsConfig contains information about a getChatGifts request.
+//
+// https://core.telegram.org/bots/api#getchatgifts
+type GetChatGiftsConfig struct {
+	ChatConfig
+	OwnedGiftsFilter
+}
+
+// Values returns url.Values representation of GetChatGiftsConfig.
+func (c GetChatGiftsConfig) Values() (url.Values, error) {
+	values := c.ChatConfig.values()
+	if values.Get("chat_id") == "" {
+		return nil, errors.New("chat_id is required")
+	}
+	if err := c.OwnedGiftsFilter.values(values, true); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for getting the gifts of a chat.
+func (GetChatGiftsConfig) TelegramMethod() string {
+	return "getChatGifts"
+}
+
+// GetBusinessAccountGiftsConfig contains information about a
+// getBusinessAccountGifts request. Requires the can_view_gifts_and_stars
+// business bot right.
+//
+// https://core.telegram.org/bots/api#getbusinessaccountgifts
+type GetBusinessAccountGiftsConfig struct {
+	// Unique identifier of the business connection
+	BusinessConnectionID string
+
+	OwnedGiftsFilter
+}
+
+// Values returns url.Values representation of GetBusinessAccountGiftsConfig.
+func (c GetBusinessAccountGiftsConfig) Values() (url.Values, error) {
+	if c.BusinessConnectionID == "" {
+		return nil, errors.New("business_connection_id is required")
+	}
+	values := url.Values{}
+	values.Add("business_connection_id", c.BusinessConnectionID)
+	if err := c.OwnedGiftsFilter.values(values, true); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for getting the gifts of a business account.
+func (GetBusinessAccountGiftsConfig) TelegramMethod() string {
+	return "getBusinessAccountGifts"
+}
+
+// ownedGiftConfig identifies a gift received by a managed business account.
+type ownedGiftConfig struct {
+	BusinessConnectionID string
+	OwnedGiftID          string
+}
+
+func (c ownedGiftConfig) values() (url.Values, error) {
+	if c.BusinessConnectionID == "" {
+		return nil, errors.New("business_connection_id is required")
+	}
+	if c.OwnedGiftID == "" {
+		return nil, errors.New("owned_gift_id is required")
+	}
+	values := url.Values{}
+	values.Add("business_connection_id", c.BusinessConnectionID)
+	values.Add("owned_gift_id", c.OwnedGiftID)
+	return values, nil
+}
+
+// ConvertGiftToStarsConfig contains information about a convertGiftToStars
+// request. Requires the can_convert_gifts_to_stars business bot right.
+//
+// https://core.telegram.org/bots/api#convertgifttostars
+type ConvertGiftToStarsConfig struct {
+	// Unique identifier of the business connection
+	BusinessConnectionID string
+
+	// Unique identifier of the regular gift that should be converted to Telegram Stars
+	OwnedGiftID string
+}
+
+// Values returns url.Values representation of ConvertGiftToStarsConfig.
+func (c ConvertGiftToStarsConfig) Values() (url.Values, error) {
+	return ownedGiftConfig(c).values()
+}
+
+// TelegramMethod returns Telegram API method name for converting a gift to Telegram Stars.
+func (ConvertGiftToStarsConfig) TelegramMethod() string {
+	return "convertGiftToStars"
+}
+
+// UpgradeGiftConfig contains information about an upgradeGift request.
+// Requires the can_transfer_and_upgrade_gifts business bot right, and the
+// can_transfer_stars right if the upgrade is paid.
+//
+// https://core.telegram.org/bots/api#upgradegift
+type UpgradeGiftConfig struct {
+	// Unique identifier of the business connection
+	BusinessConnectionID string
+
+	// Unique identifier of the regular gift that should be upgraded to a unique one
+	OwnedGiftID string
+
+	// Pass True to keep the original gift text, sender and receiver in the upgraded gift
+	KeepOriginalDetails bool
+
+	// The amount of Telegram Stars that will be paid for the upgrade from the business account balance.
+	// If gift.prepaid_upgrade_star_count > 0, then pass 0, otherwise, the can_transfer_stars business bot right is required and gift.upgrade_star_count must be passed.
+	StarCount int
+}
+
+// Values returns url.Values representation of UpgradeGiftConfig.
+func (c UpgradeGiftConfig) Values() (url.Values, error) {
+	values, err := ownedGiftConfig{BusinessConnectionID: c.BusinessConnectionID, OwnedGiftID: c.OwnedGiftID}.values()
+	if err != nil {
+		return nil, err
+	}
+	if c.KeepOriginalDetails {
+		values.Add("keep_original_details", "true")
+	}
+	if c.StarCount > 0 {
+		values.Add("star_count", strconv.Itoa(c.StarCount))
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for upgrading a gift.
+func (UpgradeGiftConfig) TelegramMethod() string {
+	return "upgradeGift"
+}
+
+// TransferGiftConfig contains information about a transferGift request.
+// Requires the can_transfer_and_upgrade_gifts business bot right, and the
+// can_transfer_stars right if the transfer is paid.
+//
+// https://core.telegram.org/bots/api#transfergift
+type TransferGiftConfig struct {
+	// Unique identifier of the business connection
+	BusinessConnectionID string
+
+	// Unique identifier of the regular gift that should be transferred
+	OwnedGiftID string
+
+	// Unique identifier of the chat which will own the gift. The chat must be active in the last 24 hours.
+	NewOwnerChatID int64
+
+	// The amount of Telegram Stars that will be paid for the transfer from the business account balance.
+	// If positive, then the can_transfer_stars business bot right is required.
+	StarCount int
+}
+
+// Values returns url.Values representation of TransferGiftConfig.
+func (c TransferGiftConfig) Values() (url.Values, error) {
+	values, err := ownedGiftConfig{BusinessConnectionID: c.BusinessConnectionID, OwnedGiftID: c.OwnedGiftID}.values()
+	if err != nil {
+		return nil, err
+	}
+	if c.NewOwnerChatID == 0 {
+		return nil, errors.New("new_owner_chat_id is required")
+	}
+	values.Add("new_owner_chat_id", strconv.FormatInt(c.NewOwnerChatID, 10))
+	if c.StarCount > 0 {
+		values.Add("star_count", strconv.Itoa(c.StarCount))
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for transferring a gift.
+func (TransferGiftConfig) TelegramMethod() string {
+	return "transferGift"
+}
+
+var (
+	_ Sendable = SendGiftConfig{}
+	_ Sendable = GiftPremiumSubscriptionConfig{}
+	_ Sendable = GetUserGiftsConfig{}
+	_ Sendable = GetChatGiftsConfig{}
+	_ Sendable = GetBusinessAccountGiftsConfig{}
+	_ Sendable = ConvertGiftToStarsConfig{}
+	_ Sendable = UpgradeGiftConfig{}
+	_ Sendable = TransferGiftConfig{}
+)
+
+// GetAvailableGifts returns the gifts that can be sent by the bot to users and channel chats.
+//
+// https://core.telegram.org/bots/api#getavailablegifts
+func (bot *BotAPI) GetAvailableGifts() (Gifts, error) {
+	return bot.GetAvailableGiftsContext(context.Background())
+}
+
+// GetAvailableGiftsContext is GetAvailableGifts using ctx for the HTTP call.
+func (bot *BotAPI) GetAvailableGiftsContext(ctx context.Context) (gifts Gifts, err error) {
+	resp, err := bot.MakeRequestContext(ctx, "getAvailableGifts", nil)
+	if err != nil {
+		return gifts, err
+	}
+	if err = json.Unmarshal(resp.Result, &gifts); err != nil {
+		return gifts, fmt.Errorf("failed to decode Telegram API response for method %q: %w", "getAvailableGifts", err)
+	}
+	return gifts, nil
+}
+
+// SendGift sends a gift to a user or a channel chat, paid from the bot's Telegram Stars balance.
+func (bot *BotAPI) SendGift(config SendGiftConfig) (APIResponse, error) {
+	return bot.SendGiftContext(context.Background(), config)
+}
+
+// SendGiftContext is SendGift using ctx for the HTTP call.
+func (bot *BotAPI) SendGiftContext(ctx context.Context, config SendGiftConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// GiftPremiumSubscription gifts a Telegram Premium subscription to a user,
+// paid from the bot's Telegram Stars balance.
+func (bot *BotAPI) GiftPremiumSubscription(config GiftPremiumSubscriptionConfig) (APIResponse, error) {
+	return bot.GiftPremiumSubscriptionContext(context.Background(), config)
+}
+
+// GiftPremiumSubscriptionContext is GiftPremiumSubscription using ctx for the HTTP call.
+func (bot *BotAPI) GiftPremiumSubscriptionContext(ctx context.Context, config GiftPremiumSubscriptionConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// GetUserGifts returns the gifts owned and hosted by a user.
+func (bot *BotAPI) GetUserGifts(config GetUserGiftsConfig) (OwnedGifts, error) {
+	return bot.GetUserGiftsContext(context.Background(), config)
+}
+
+// GetUserGiftsContext is GetUserGifts using ctx for the HTTP call.
+func (bot *BotAPI) GetUserGiftsContext(ctx context.Context, config GetUserGiftsConfig) (gifts OwnedGifts, err error) {
+	err = bot.SendCustomMessage(ctx, config, &gifts)
+	return
+}
+
+// GetChatGifts returns the gifts owned by a chat.
+func (bot *BotAPI) GetChatGifts(config GetChatGiftsConfig) (OwnedGifts, error) {
+	return bot.GetChatGiftsContext(context.Background(), config)
+}
+
+// GetChatGiftsContext is GetChatGifts using ctx for the HTTP call.
+func (bot *BotAPI) GetChatGiftsContext(ctx context.Context, config GetChatGiftsConfig) (gifts OwnedGifts, err error) {
+	err = bot.SendCustomMessage(ctx, config, &gifts)
+	return
+}
+
+// GetBusinessAccountGifts returns the gifts received and owned by a managed business account.
+func (bot *BotAPI) GetBusinessAccountGifts(config GetBusinessAccountGiftsConfig) (OwnedGifts, error) {
+	return bot.GetBusinessAccountGiftsContext(context.Background(), config)
+}
+
+// GetBusinessAccountGiftsContext is GetBusinessAccountGifts using ctx for the HTTP call.
+func (bot *BotAPI) GetBusinessAccountGiftsContext(ctx context.Context, config GetBusinessAccountGiftsConfig) (gifts OwnedGifts, err error) {
+	err = bot.SendCustomMessage(ctx, config, &gifts)
+	return
+}
+
+// ConvertGiftToStars converts a regular gift of a managed business account to Telegram Stars.
+func (bot *BotAPI) ConvertGiftToStars(config ConvertGiftToStarsConfig) (APIResponse, error) {
+	return bot.ConvertGiftToStarsContext(context.Background(), config)
+}
+
+// ConvertGiftToStarsContext is ConvertGiftToStars using ctx for the HTTP call.
+func (bot *BotAPI) ConvertGiftToStarsContext(ctx context.Context, config ConvertGiftToStarsConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// UpgradeGift upgrades a regular gift of a managed business account to a unique gift.
+func (bot *BotAPI) UpgradeGift(config UpgradeGiftConfig) (APIResponse, error) {
+	return bot.UpgradeGiftContext(context.Background(), config)
+}
+
+// UpgradeGiftContext is UpgradeGift using ctx for the HTTP call.
+func (bot *BotAPI) UpgradeGiftContext(ctx context.Context, config UpgradeGiftConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// TransferGift transfers a unique gift owned by a managed business account to another user.
+func (bot *BotAPI) TransferGift(config TransferGiftConfig) (APIResponse, error) {
+	return bot.TransferGiftContext(context.Background(), config)
+}
+
+// TransferGiftContext is TransferGift using ctx for the HTTP call.
+func (bot *BotAPI) TransferGiftContext(ctx context.Context, config TransferGiftConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
@@ -0,0 +1,124 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestOwnedGifts_UnmarshalJSON(t *testing.T) {
+	var gifts OwnedGifts
+	err := json.Unmarshal([]byte(`{"total_count":3,"next_offset":"abc","gifts":[
+		{"type":"regular","owned_gift_id":"g1","send_date":1700000000,"gift":{"id":"rose","star_count":15},"convert_star_count":10,"can_be_upgraded":true},
+		{"type":"unique","owned_gift_id":"g2","sender_user":{"id":7,"first_name":"A"},"gift":{"base_name":"Rose","name":"Rose-1","number":1},"can_be_transferred":true,"transfer_star_count":25},
+		{"type":"future","owned_gift_id":"g3"}
+	]}`), &gifts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gifts.TotalCount != 3 || gifts.NextOffset != "abc" || len(gifts.Gifts) != 2 {
+		t.Fatalf("gifts = %+v", gifts)
+	}
+	regular, ok := gifts.Gifts[0].(*OwnedGiftRegular)
+	if !ok || regular.GetOwnedGiftID() != "g1" || regular.Gift.ID != "rose" || regular.ConvertStarCount != 10 || !regular.CanBeUpgraded {
+		t.Errorf("gifts[0] = %#v", gifts.Gifts[0])
+	}
+	unique, ok := gifts.Gifts[1].(*OwnedGiftUnique)
+	if !ok || unique.GetType() != OwnedGiftTypeUnique || unique.GetSenderUser().ID != 7 || unique.Gift.Name != "Rose-1" || unique.TransferStarCount != 25 {
+		t.Errorf("gifts[1] = %#v", gifts.Gifts[1])
+	}
+}
+
+func TestGiftConfigs_Values(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		config Sendable
+		want   map[string]string
+	}{
+		{
+			name:   "send_to_user",
+			config: SendGiftConfig{UserID: 7, GiftID: "rose", PayForUpgrade: true, Text: "Hi"},
+			want:   map[string]string{"user_id": "7", "chat_id": "", "gift_id": "rose", "pay_for_upgrade": "true", "text": "Hi"},
+		},
+		{
+			name:   "send_to_channel",
+			config: SendGiftConfig{ChannelUsername: "@news", GiftID: "rose"},
+			want:   map[string]string{"chat_id": "@news", "user_id": ""},
+		},
+		{
+			name:   "premium_default_price",
+			config: GiftPremiumSubscriptionConfig{UserID: 7, MonthCount: 6},
+			want:   map[string]string{"month_count": "6", "star_count": "1500"},
+		},
+		{
+			name:   "user_gifts",
+			config: GetUserGiftsConfig{UserID: 7, OwnedGiftsFilter: OwnedGiftsFilter{ExcludeSaved: true, ExcludeUnique: true, Offset: "abc", Limit: 10}},
+			want:   map[string]string{"exclude_saved": "", "exclude_unique": "true", "offset": "abc", "limit": "10"},
+		},
+		{
+			name:   "chat_gifts",
+			config: GetChatGiftsConfig{ChatConfig: ChatConfig{ChatID: -100}, OwnedGiftsFilter: OwnedGiftsFilter{ExcludeUnsaved: true, SortByPrice: true}},
+			want:   map[string]string{"chat_id": "-100", "exclude_unsaved": "true", "sort_by_price": "true"},
+		},
+		{
+			name:   "upgrade",
+			config: UpgradeGiftConfig{BusinessConnectionID: "bc", OwnedGiftID: "g1", KeepOriginalDetails: true, StarCount: 25},
+			want:   map[string]string{"business_connection_id": "bc", "owned_gift_id": "g1", "keep_original_details": "true", "star_count": "25"},
+		},
+		{
+			name:   "transfer",
+			config: TransferGiftConfig{BusinessConnectionID: "bc", OwnedGiftID: "g2", NewOwnerChatID: 9},
+			want:   map[string]string{"new_owner_chat_id": "9", "star_count": ""},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := tt.config.Values()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for key, want := range tt.want {
+				if got := values.Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGiftConfigs_Validation(t *testing.T) {
+	for name, config := range map[string]Sendable{
+		"gift_without_receiver":  SendGiftConfig{GiftID: "rose"},
+		"gift_to_user_and_chat":  SendGiftConfig{UserID: 7, ChatID: -100, GiftID: "rose"},
+		"gift_without_id":        NewSendGift(7, ""),
+		"premium_month_count":    GiftPremiumSubscriptionConfig{UserID: 7, MonthCount: 1},
+		"premium_star_count":     GiftPremiumSubscriptionConfig{UserID: 7, MonthCount: 3, StarCount: 1500},
+		"gifts_limit":            GetUserGiftsConfig{UserID: 7, OwnedGiftsFilter: OwnedGiftsFilter{Limit: 101}},
+		"business_without_id":    GetBusinessAccountGiftsConfig{},
+		"convert_without_gift":   ConvertGiftToStarsConfig{BusinessConnectionID: "bc"},
+		"transfer_without_owner": TransferGiftConfig{BusinessConnectionID: "bc", OwnedGiftID: "g1"},
+	} {
+		if _, err := config.Values(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestGetGifts(t *testing.T) {
+	bot := testBotWithResponse("123456:TOKEN", http.StatusOK, `{"ok":true,"result":{"gifts":[{"id":"rose","star_count":15,"sticker":{"file_id":"s"}}]}}`)
+	available, err := bot.GetAvailableGifts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(available.Gifts) != 1 || available.Gifts[0].StarCount != 15 {
+		t.Errorf("available = %+v", available)
+	}
+
+	bot = testBotWithResponse("123456:TOKEN", http.StatusOK, `{"ok":true,"result":{"total_count":1,"gifts":[{"type":"regular","gift":{"id":"rose"}}]}}`)
+	owned, err := bot.GetBusinessAccountGifts(GetBusinessAccountGiftsConfig{BusinessConnectionID: "bc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(owned.Gifts) != 1 || owned.Gifts[0].GetType() != OwnedGiftTypeRegular {
+		t.Errorf("owned = %+v", owned)
+	}
+}
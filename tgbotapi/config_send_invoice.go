@@ -1,8 +1,10 @@
 package tgbotapi
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
 )
 
 type LabeledPrice struct {
@@ -42,11 +44,71 @@ func (*InvoiceConfig) TelegramMethod() string {
 	return "sendInvoice"
 }
 
+// CurrencyStars is the currency of payments in Telegram Stars.
+const CurrencyStars = "XTR"
+
+// Validate returns an error if the invoice can't be sent: a required field
+// is missing or too long, the tips are inconsistent, or a payment in
+// Telegram Stars uses a provider token, tips or more than one price.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v *InvoiceConfig) Validate() error {
+	switch {
+	case v.Title == "" || len([]rune(v.Title)) > 32:
+		return fmt.Errorf("invoice title must be 1-32 characters long, got %d", len([]rune(v.Title)))
+	case v.Description == "" || len([]rune(v.Description)) > 255:
+		return fmt.Errorf("invoice description must be 1-255 characters long, got %d", len([]rune(v.Description)))
+	case v.Payload == "" || len(v.Payload) > 128:
+		return fmt.Errorf("invoice payload must be 1-128 bytes long, got %d", len(v.Payload))
+	case len(v.Currency) != 3:
+		return fmt.Errorf("invoice currency must be a three-letter ISO 4217 code, got %q", v.Currency)
+	case len(v.Prices) == 0:
+		return errors.New("invoice requires at least one price")
+	}
+	if v.Currency == CurrencyStars {
+		switch {
+		case v.ProviderToken != "":
+			return errors.New("invoice in Telegram Stars must not have a provider token")
+		case len(v.Prices) != 1:
+			return fmt.Errorf("invoice in Telegram Stars must have exactly one price, got %d", len(v.Prices))
+		case v.MaxTipAmount != 0 || len(v.SuggestedTipAmounts) > 0:
+			return errors.New("tips are not supported for payments in Telegram Stars")
+		}
+	}
+	return validateTips(v.MaxTipAmount, v.SuggestedTipAmounts)
+}
+
+func validateTips(maxTipAmount int64, suggestedTipAmounts []int64) error {
+	if maxTipAmount < 0 {
+		return fmt.Errorf("max tip amount must not be negative, got %d", maxTipAmount)
+	}
+	if len(suggestedTipAmounts) > 4 {
+		return fmt.Errorf("at most 4 suggested tip amounts can be specified, got %d", len(suggestedTipAmounts))
+	}
+	for i, amount := range suggestedTipAmounts {
+		switch {
+		case amount <= 0:
+			return fmt.Errorf("suggested tip amounts must be positive, got %d", amount)
+		case i > 0 && amount <= suggestedTipAmounts[i-1]:
+			return errors.New("suggested tip amounts must be in a strictly increasing order")
+		case amount > maxTipAmount:
+			return fmt.Errorf("suggested tip amount %d exceeds max tip amount %d", amount, maxTipAmount)
+		}
+	}
+	return nil
+}
+
 // Values returns url.Values representation of InvoiceConfig.
 //
 //goland:noinspection GoMixedReceiverTypes
 func (v *InvoiceConfig) Values() (url.Values, error) {
-	values, _ := v.BaseChat.Values()
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	values, err := v.BaseChat.Values()
+	if err != nil {
+		return nil, err
+	}
 	values.Add("title", v.Title)
 	values.Add("description", v.Description)
 	values.Add("payload", v.Payload)
@@ -54,12 +116,58 @@ func (v *InvoiceConfig) Values() (url.Values, error) {
 	if v.ProviderToken != "" {
 		values.Add("provider_token", v.ProviderToken)
 	}
-	if len(v.Prices) > 0 {
-		if b, err := encodeToJson(v.Prices); err != nil {
-			return nil, fmt.Errorf("failed to marshal invoice prices as JSON: %v", err)
-		} else {
-			values.Add("prices", string(b))
+	b, err := encodeToJson(v.Prices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal invoice prices as JSON: %w", err)
+	}
+	values.Add("prices", string(b))
+	if v.MaxTipAmount != 0 {
+		values.Add("max_tip_amount", strconv.FormatInt(v.MaxTipAmount, 10))
+	}
+	if len(v.SuggestedTipAmounts) > 0 {
+		if b, err = encodeToJson(v.SuggestedTipAmounts); err != nil {
+			return nil, fmt.Errorf("failed to marshal suggested_tip_amounts as JSON: %w", err)
 		}
+		values.Add("suggested_tip_amounts", string(b))
+	}
+	if v.StartParameter != "" {
+		values.Add("start_parameter", v.StartParameter)
+	}
+	if v.ProviderData != "" {
+		values.Add("provider_data", v.ProviderData)
+	}
+	if v.PhotoURL != "" {
+		values.Add("photo_url", v.PhotoURL)
+	}
+	if v.PhotoSize != 0 {
+		values.Add("photo_size", strconv.Itoa(v.PhotoSize))
+	}
+	if v.PhotoWidth != 0 {
+		values.Add("photo_width", strconv.Itoa(v.PhotoWidth))
+	}
+	if v.PhotoHeight != 0 {
+		values.Add("photo_height", strconv.Itoa(v.PhotoHeight))
+	}
+	if v.NeedName {
+		values.Add("need_name", "true")
+	}
+	if v.NeedPhoneNumber {
+		values.Add("need_phone_number", "true")
+	}
+	if v.NeedEmail {
+		values.Add("need_email", "true")
+	}
+	if v.NeedShippingAddress {
+		values.Add("need_shipping_address", "true")
+	}
+	if v.SendPhoneNumberToProvider {
+		values.Add("send_phone_number_to_provider", "true")
+	}
+	if v.SendEmailToProvider {
+		values.Add("send_email_to_provider", "true")
+	}
+	if v.IsFlexible {
+		values.Add("is_flexible", "true")
 	}
 	return values, nil
 }
@@ -0,0 +1,117 @@
+package tgbotapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInvoiceConfig_Values_allFields(t *testing.T) {
+	cfg := &InvoiceConfig{
+		BaseChat:                  BaseChat{ChatID: 1},
+		Title:                     "T",
+		Description:               "D",
+		Payload:                   "P",
+		ProviderToken:             "prov_123",
+		Currency:                  "EUR",
+		Prices:                    []LabeledPrice{{Label: "x", Amount: 100}},
+		MaxTipAmount:              500,
+		SuggestedTipAmounts:       []int64{100, 200},
+		StartParameter:            "start",
+		ProviderData:              `{"k":"v"}`,
+		PhotoURL:                  "https://img",
+		PhotoSize:                 1,
+		PhotoWidth:                2,
+		PhotoHeight:               3,
+		NeedName:                  true,
+		NeedPhoneNumber:           true,
+		NeedEmail:                 true,
+		NeedShippingAddress:       true,
+		SendPhoneNumberToProvider: true,
+		SendEmailToProvider:       true,
+		IsFlexible:                true,
+	}
+	v, err := cfg.Values()
+	if err != nil {
+		t.Fatalf("Values: %v", err)
+	}
+	checks := map[string]string{
+		"chat_id":                       "1",
+		"provider_token":                "prov_123",
+		"max_tip_amount":                "500",
+		"start_parameter":               "start",
+		"provider_data":                 `{"k":"v"}`,
+		"photo_url":                     "https://img",
+		"photo_size":                    "1",
+		"photo_width":                   "2",
+		"photo_height":                  "3",
+		"need_name":                     "true",
+		"need_phone_number":             "true",
+		"need_email":                    "true",
+		"need_shipping_address":         "true",
+		"send_phone_number_to_provider": "true",
+		"send_email_to_provider":        "true",
+		"is_flexible":                   "true",
+	}
+	for key, want := range checks {
+		if got := v.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	if got := strings.TrimSpace(v.Get("suggested_tip_amounts")); got != "[100,200]" {
+		t.Errorf("suggested_tip_amounts = %q", got)
+	}
+}
+
+func TestInvoiceConfig_Validate(t *testing.T) {
+	valid := func() *InvoiceConfig {
+		return &InvoiceConfig{Title: "T", Description: "D", Payload: "P", Currency: CurrencyStars, Prices: []LabeledPrice{{Label: "x", Amount: 50}}}
+	}
+	if err := valid().Validate(); err != nil {
+		t.Fatalf("stars invoice: %v", err)
+	}
+	for name, modify := range map[string]func(*InvoiceConfig){
+		"no_title":         func(c *InvoiceConfig) { c.Title = "" },
+		"long_payload":     func(c *InvoiceConfig) { c.Payload = strings.Repeat("p", 129) },
+		"bad_currency":     func(c *InvoiceConfig) { c.Currency = "EURO" },
+		"no_prices":        func(c *InvoiceConfig) { c.Prices = nil },
+		"stars_provider":   func(c *InvoiceConfig) { c.ProviderToken = "prov" },
+		"stars_two_prices": func(c *InvoiceConfig) { c.Prices = append(c.Prices, LabeledPrice{Label: "y", Amount: 1}) },
+		"stars_tips":       func(c *InvoiceConfig) { c.MaxTipAmount = 10 },
+		"tips_not_increasing": func(c *InvoiceConfig) {
+			c.Currency, c.MaxTipAmount, c.SuggestedTipAmounts = "USD", 500, []int64{200, 100}
+		},
+		"tip_over_max": func(c *InvoiceConfig) { c.Currency, c.MaxTipAmount, c.SuggestedTipAmounts = "USD", 100, []int64{200} },
+		"too_many_tips": func(c *InvoiceConfig) {
+			c.Currency, c.MaxTipAmount, c.SuggestedTipAmounts = "USD", 500, []int64{1, 2, 3, 4, 5}
+		},
+	} {
+		c := valid()
+		modify(c)
+		if _, err := c.Values(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestAnswerShippingQueryConfig_Values(t *testing.T) {
+	v, err := AnswerShippingQueryWithOK("q1", NewShippingOption("post", "Post", LabeledPrice{Label: "Delivery", Amount: 500})).Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Get("ok") != "true" || !strings.Contains(v.Get("shipping_options"), `"id":"post"`) {
+		t.Errorf("values = %v", v)
+	}
+	v, err = AnswerShippingQueryWithNotOK("q1", "No delivery").Values()
+	if err != nil || v.Get("ok") != "false" || v.Get("error_message") != "No delivery" || v.Has("shipping_options") {
+		t.Errorf("values = %v, err = %v", v, err)
+	}
+	for name, c := range map[string]AnswerShippingQueryConfig{
+		"ok_without_options":   AnswerShippingQueryWithOK("q1"),
+		"not_ok_without_error": {ShippingQueryID: "q1"},
+		"option_without_price": AnswerShippingQueryWithOK("q1", NewShippingOption("post", "Post")),
+	} {
+		if _, err = c.Values(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
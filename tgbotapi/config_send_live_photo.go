@@ -1,7 +1,6 @@
 package tgbotapi
 
 import (
-	"fmt"
 	"net/url"
 )
 
@@ -20,19 +19,27 @@ type LivePhotoConfig struct {
 }
 
 // Values returns url.Values representation of LivePhotoConfig.
+// A photo to upload is only supported by multipart.
 func (v LivePhotoConfig) Values() (url.Values, error) {
-	const livePhotoParamName = "live_photo"
-
-	values, _ := v.BaseChat.Values()
+	values := v.fields()
+	if err := addPhotoReference(values, "live_photo", v.Photo); err != nil {
+		return values, err
+	}
+	return values, nil
+}
 
-	switch photoType := v.Photo.PhotoType(); photoType {
-	case PhotoTypeUrl:
-		values.Add(livePhotoParamName, string(v.Photo.(PhotoUrl)))
-	case PhotoTypeFileID:
-		values.Add(livePhotoParamName, string(v.Photo.(FileID)))
-	case PhotoTypeInputFile:
-		return values, fmt.Errorf("not implemented yet for photo type: %v", photoType)
+// multipart returns the sendLivePhoto request, uploading the photo if needed.
+func (v LivePhotoConfig) multipart() (*multipartRequest, error) {
+	r := newMultipartRequest(paramsFromValues(v.fields()))
+	if err := r.addFile("live_photo", photoInputFile(v.Photo)); err != nil {
+		return nil, err
 	}
+	return r, nil
+}
+
+// fields returns the request fields except the live photo.
+func (v LivePhotoConfig) fields() url.Values {
+	values, _ := v.BaseChat.Values()
 	if v.Caption != "" {
 		values.Add("caption", v.Caption)
 	}
@@ -42,10 +49,12 @@ func (v LivePhotoConfig) Values() (url.Values, error) {
 	if v.HasSpoiler {
 		values.Add("has_spoiler", "true")
 	}
-	return values, nil
+	return values
 }
 
 // TelegramMethod returns Telegram API method name for sending a LivePhoto.
 func (LivePhotoConfig) TelegramMethod() string {
 	return "sendLivePhoto"
 }
+
+var _ multipartSendable = LivePhotoConfig{}
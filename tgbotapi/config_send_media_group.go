@@ -0,0 +1,141 @@
+package tgbotapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// MediaGroupItem is one item of an album sent with MediaGroupConfig.
+type MediaGroupItem struct {
+	// Media is an InputMediaAudio, InputMediaDocument, InputMediaPhoto or
+	// InputMediaVideo describing the item. Its Media, Thumbnail and Cover
+	// fields are set from the files below.
+	Media any
+
+	// File is the file to send.
+	File InputFile
+
+	// Thumbnail and Cover are optional. Photos have neither and only videos
+	// have a cover; setting one the media has no field for is an error.
+	Thumbnail InputFile
+	Cover     InputFile
+}
+
+// NewMediaGroupPhoto creates a photo item for a media group.
+func NewMediaGroupPhoto(file InputFile) MediaGroupItem {
+	return MediaGroupItem{Media: InputMediaPhoto{Type: "photo"}, File: file}
+}
+
+// NewMediaGroupVideo creates a video item for a media group.
+func NewMediaGroupVideo(file InputFile) MediaGroupItem {
+	return MediaGroupItem{Media: InputMediaVideo{Type: "video"}, File: file}
+}
+
+// NewMediaGroupAudio creates an audio item for a media group.
+func NewMediaGroupAudio(file InputFile) MediaGroupItem {
+	return MediaGroupItem{Media: InputMediaAudio{Type: "audio"}, File: file}
+}
+
+// NewMediaGroupDocument creates a document item for a media group.
+func NewMediaGroupDocument(file InputFile) MediaGroupItem {
+	return MediaGroupItem{Media: InputMediaDocument{Type: "document"}, File: file}
+}
+
+// MediaGroupConfig contains information about a sendMediaGroup request.
+// Files to upload are sent in the same multipart request and referenced from
+// the media JSON as attach://<name>.
+//
+// https://core.telegram.org/bots/api#sendmediagroup
+type MediaGroupConfig struct {
+	BaseChat
+
+	// 2-10 items. Documents and audio files can only be grouped with items of
+	// the same type; photos and videos can be mixed.
+	Media []MediaGroupItem
+}
+
+// NewMediaGroup creates a media group to send to chatID.
+func NewMediaGroup(chatID int64, media ...MediaGroupItem) MediaGroupConfig {
+	return MediaGroupConfig{
+		BaseChat: BaseChat{ChatID: chatID},
+		Media:    media,
+	}
+}
+
+// TelegramMethod returns Telegram method name
+func (MediaGroupConfig) TelegramMethod() string {
+	return "sendMediaGroup"
+}
+
+// Values returns url.Values representation of MediaGroupConfig.
+// Media to upload is only supported by multipart.
+func (v MediaGroupConfig) Values() (url.Values, error) {
+	r, err := v.multipart()
+	if err != nil {
+		return nil, err
+	}
+	if len(r.parts) > 0 {
+		return nil, errors.New("media group to upload must be sent as multipart")
+	}
+	return r.values(), nil
+}
+
+// multipart returns the request with the media JSON and the files to upload.
+func (v MediaGroupConfig) multipart() (*multipartRequest, error) {
+	if len(v.Media) < 2 || len(v.Media) > 10 {
+		return nil, fmt.Errorf("media group must contain 2-10 items, got %d", len(v.Media))
+	}
+
+	values, err := v.BaseChat.Values()
+	if err != nil {
+		return nil, err
+	}
+	r := newMultipartRequest(paramsFromValues(values))
+
+	media := make([]any, len(v.Media))
+	var groupType string
+	for i, item := range v.Media {
+		if item.File.IsZero() {
+			return nil, fmt.Errorf("media group item %d: file is required", i)
+		}
+		var refs inputMediaRefs
+		if refs, err = item.attach(r); err != nil {
+			return nil, fmt.Errorf("media group item %d: %w", i, err)
+		}
+		var mediaType string
+		if media[i], mediaType, err = withInputMediaRefs(item.Media, refs); err != nil {
+			return nil, fmt.Errorf("media group item %d: %w", i, err)
+		}
+		switch mediaType {
+		case "photo", "video":
+			mediaType = "video" // photos and videos can be mixed
+		case "audio", "document":
+		default:
+			return nil, fmt.Errorf("media group item %d: %s can't be sent in a media group", i, mediaType)
+		}
+		if groupType != "" && groupType != mediaType {
+			return nil, errors.New("documents and audio files can only be grouped with items of the same type")
+		}
+		groupType = mediaType
+	}
+
+	if err = r.addJSON("media", media); err != nil {
+		return nil, fmt.Errorf("failed to marshal media as JSON: %w", err)
+	}
+	return r, nil
+}
+
+// attach adds the files of the item to r and returns references to them.
+func (item MediaGroupItem) attach(r *multipartRequest) (refs inputMediaRefs, err error) {
+	if refs.media, err = r.attach(item.File); err != nil {
+		return
+	}
+	if refs.thumbnail, err = r.attach(item.Thumbnail); err != nil {
+		return
+	}
+	refs.cover, err = r.attach(item.Cover)
+	return
+}
+
+var _ multipartSendable = MediaGroupConfig{}
@@ -0,0 +1,130 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSendMediaGroup_UploadsFilesInOneRequest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "local.jpg")
+	if err := os.WriteFile(path, []byte("local-bytes"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var requests int
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			requests++
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatal(err)
+			}
+			var media []map[string]any
+			if err := json.Unmarshal([]byte(r.FormValue("media")), &media); err != nil {
+				t.Fatal(err)
+			}
+			wantMedia := []string{"existing-id", "https://example.com/a.jpg", "attach://file0", "attach://file1", "attach://file2"}
+			for i, want := range wantMedia {
+				if media[i]["media"] != want {
+					t.Errorf("media[%d] = %v, want %q", i, media[i]["media"], want)
+				}
+			}
+			if media[4]["type"] != "video" || media[0]["caption"] != "first" {
+				t.Errorf("media = %v", media)
+			}
+			wantFiles := map[string]string{"file0": "local-bytes", "file1": "in-memory", "file2": "streamed"}
+			for field, want := range wantFiles {
+				file, _, err := r.FormFile(field)
+				if err != nil {
+					t.Fatalf("%s: %v", field, err)
+				}
+				got, _ := io.ReadAll(file)
+				if string(got) != want {
+					t.Errorf("%s = %q, want %q", field, got, want)
+				}
+			}
+			if r.FormValue("chat_id") != "42" {
+				t.Errorf("chat_id = %q", r.FormValue("chat_id"))
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"ok":true,"result":[{"message_id":1},{"message_id":2}]}`)),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	})
+
+	first := NewMediaGroupPhoto(NewInputFileID("existing-id"))
+	first.Media = InputMediaPhoto{Caption: "first"}
+	config := NewMediaGroup(42,
+		first,
+		NewMediaGroupPhoto(NewInputFileURL("https://example.com/a.jpg")),
+		NewMediaGroupPhoto(NewInputFilePath(path)),
+		NewMediaGroupPhoto(NewInputFileBytes("mem.jpg", []byte("in-memory"))),
+		NewMediaGroupVideo(NewInputFileReader("v.mp4", strings.NewReader("streamed"))),
+	)
+	messages, err := bot.SendMediaGroup(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 || len(messages) != 2 || messages[1].MessageID != 2 {
+		t.Fatalf("requests = %d, messages = %+v", requests, messages)
+	}
+}
+
+func TestMediaGroupConfig_Validation(t *testing.T) {
+	a, b := NewInputFileID("a"), NewInputFileID("b")
+	tests := map[string]MediaGroupConfig{
+		"too few":      NewMediaGroup(1, NewMediaGroupPhoto(a)),
+		"mixed types":  NewMediaGroup(1, NewMediaGroupPhoto(a), NewMediaGroupDocument(b)),
+		"missing file": NewMediaGroup(1, NewMediaGroupPhoto(a), NewMediaGroupPhoto(InputFile{})),
+		"bad media":    NewMediaGroup(1, NewMediaGroupPhoto(a), MediaGroupItem{Media: InputMediaSticker{}, File: b}),
+		"voice note":   NewMediaGroup(1, NewMediaGroupPhoto(a), MediaGroupItem{Media: InputMediaVoiceNote{}, File: b}),
+		"photo thumb":  NewMediaGroup(1, NewMediaGroupPhoto(a), MediaGroupItem{Media: InputMediaPhoto{}, File: b, Thumbnail: a}),
+		"audio cover":  NewMediaGroup(1, NewMediaGroupAudio(a), MediaGroupItem{Media: InputMediaAudio{}, File: b, Cover: a}),
+	}
+	for name, config := range tests {
+		if _, err := config.multipart(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	video := NewMediaGroupVideo(b)
+	video.Thumbnail = NewInputFileBytes("thumb.jpg", []byte("thumb"))
+	ok := NewMediaGroup(1, NewMediaGroupPhoto(a), video)
+	r, err := ok.multipart()
+	if err != nil || len(r.parts) != 1 {
+		t.Fatalf("photo and video: r=%+v err=%v", r, err)
+	}
+	if !strings.Contains(r.params["media"], `"thumbnail":"attach://file0"`) {
+		t.Fatalf("media = %s", r.params["media"])
+	}
+}
+
+func TestMediaGroupConfig_Send(t *testing.T) {
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			return nil, io.EOF
+		}),
+	})
+	config := NewMediaGroup(42, NewMediaGroupPhoto(NewInputFileID("a")), NewMediaGroupPhoto(NewInputFileID("b")))
+	if _, err := bot.Send(config); err == nil || !strings.Contains(err.Error(), "SendMediaGroup") {
+		t.Errorf("Send: err = %v", err)
+	}
+
+	values, err := config.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Get("chat_id") != "42" || !strings.Contains(values.Get("media"), `"media":"b"`) {
+		t.Errorf("values = %v", values)
+	}
+	config.Media[1].File = NewInputFileBytes("b.jpg", []byte("b"))
+	if _, err = config.Values(); err == nil {
+		t.Error("Values of a media group to upload: expected error")
+	}
+}
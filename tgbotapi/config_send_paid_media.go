@@ -0,0 +1,223 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// MaxPaidMediaStarCount is the maximum price of paid media in Telegram Stars.
+const MaxPaidMediaStarCount = 10000
+
+// PaidMediaItem is one item of paid media sent with PaidMediaConfig.
+type PaidMediaItem struct {
+	// Media is an InputPaidMediaPhoto, InputPaidMediaVideo or
+	// InputPaidMediaLivePhoto describing the item. Its Media, Thumbnail and
+	// Cover fields are set from the files below.
+	Media any
+
+	// File is the file to send.
+	File InputFile
+
+	// Thumbnail and Cover are optional and only videos have them; setting
+	// them on other media is an error.
+	Thumbnail InputFile
+	Cover     InputFile
+}
+
+// NewPaidMediaPhoto creates a photo item of paid media.
+func NewPaidMediaPhoto(file InputFile) PaidMediaItem {
+	return PaidMediaItem{Media: InputPaidMediaPhoto{Type: "photo"}, File: file}
+}
+
+// NewPaidMediaVideo creates a video item of paid media.
+func NewPaidMediaVideo(file InputFile) PaidMediaItem {
+	return PaidMediaItem{Media: InputPaidMediaVideo{Type: "video"}, File: file}
+}
+
+// NewPaidMediaLivePhoto creates a live photo item of paid media.
+func NewPaidMediaLivePhoto(file InputFile) PaidMediaItem {
+	return PaidMediaItem{Media: InputPaidMediaLivePhoto{Type: "live_photo"}, File: file}
+}
+
+// PaidMediaConfig contains information about a sendPaidMedia request. Files
+// to upload are sent in the same multipart request and referenced from the
+// media JSON as attach://<name>.
+//
+// https://core.telegram.org/bots/api#sendpaidmedia
+type PaidMediaConfig struct {
+	BaseChat
+
+	// The number of Telegram Stars that must be paid to buy access to the media; 1-10000
+	StarCount int
+
+	// 1-10 items of paid media
+	Media []PaidMediaItem
+
+	// Bot-defined paid media payload, 0-128 bytes. This will not be displayed to the user, use it for your internal processes.
+	// It is returned in Update.PurchasedPaidMedia, see Dispatcher.OnPaidMediaPayload.
+	Payload string
+
+	// Media caption, 0-1024 characters after entities parsing
+	Caption string
+
+	// Mode for parsing entities in the media caption
+	ParseMode string
+
+	// A JSON-serialized list of special entities that appear in the caption, which can be specified instead of parse_mode
+	CaptionEntities []MessageEntity
+
+	// Pass True, if the caption must be shown above the message media
+	ShowCaptionAboveMedia bool
+}
+
+// NewPaidMedia creates paid media to send to chatID for starCount Telegram Stars.
+func NewPaidMedia(chatID int64, starCount int, media ...PaidMediaItem) PaidMediaConfig {
+	return PaidMediaConfig{
+		BaseChat:  BaseChat{ChatID: chatID},
+		StarCount: starCount,
+		Media:     media,
+	}
+}
+
+// TelegramMethod returns Telegram API method name for sending paid media.
+func (PaidMediaConfig) TelegramMethod() string {
+	return "sendPaidMedia"
+}
+
+// Values returns url.Values representation of PaidMediaConfig.
+// Media to upload is only supported by multipart.
+func (v PaidMediaConfig) Values() (url.Values, error) {
+	r, err := v.multipart()
+	if err != nil {
+		return nil, err
+	}
+	if len(r.parts) > 0 {
+		return nil, errors.New("paid media to upload must be sent as multipart")
+	}
+	return r.values(), nil
+}
+
+// multipart returns the request with the media JSON and the files to upload.
+func (v PaidMediaConfig) multipart() (*multipartRequest, error) {
+	if v.StarCount < 1 || v.StarCount > MaxPaidMediaStarCount {
+		return nil, fmt.Errorf("star count must be 1-%d, got %d", MaxPaidMediaStarCount, v.StarCount)
+	}
+	if len(v.Media) < 1 || len(v.Media) > 10 {
+		return nil, fmt.Errorf("paid media must contain 1-10 items, got %d", len(v.Media))
+	}
+	if len(v.Payload) > 128 {
+		return nil, fmt.Errorf("paid media payload must be at most 128 bytes, got %d", len(v.Payload))
+	}
+
+	values, err := v.BaseChat.Values()
+	if err != nil {
+		return nil, err
+	}
+	values.Add("star_count", strconv.Itoa(v.StarCount))
+	if v.Payload != "" {
+		values.Add("payload", v.Payload)
+	}
+	if v.Caption != "" {
+		values.Add("caption", v.Caption)
+	}
+	if v.ParseMode != "" {
+		values.Add("parse_mode", v.ParseMode)
+	}
+	if len(v.CaptionEntities) > 0 {
+		data, err := json.Marshal(v.CaptionEntities)
+		if err != nil {
+			return nil, err
+		}
+		values.Add("caption_entities", string(data))
+	}
+	if v.ShowCaptionAboveMedia {
+		values.Add("show_caption_above_media", "true")
+	}
+	r := newMultipartRequest(paramsFromValues(values))
+
+	media := make([]any, len(v.Media))
+	for i, item := range v.Media {
+		if item.File.IsZero() {
+			return nil, fmt.Errorf("paid media item %d: file is required", i)
+		}
+		var refs inputMediaRefs
+		if refs, err = item.attach(r); err != nil {
+			return nil, fmt.Errorf("paid media item %d: %w", i, err)
+		}
+		if media[i], err = withInputPaidMediaRefs(item.Media, refs); err != nil {
+			return nil, fmt.Errorf("paid media item %d: %w", i, err)
+		}
+	}
+
+	if err = r.addJSON("media", media); err != nil {
+		return nil, fmt.Errorf("failed to marshal media as JSON: %w", err)
+	}
+	return r, nil
+}
+
+// attach adds the files of the item to r and returns references to them.
+func (item PaidMediaItem) attach(r *multipartRequest) (refs inputMediaRefs, err error) {
+	return MediaGroupItem(item).attach(r)
+}
+
+// withInputPaidMediaRefs returns a copy of an InputPaidMedia* value, or of
+// the value a pointer to one refers to, with refs applied and Type set if empty.
+func withInputPaidMediaRefs(media any, refs inputMediaRefs) (any, error) {
+	set := func(field *string, ref string) {
+		if ref != "" {
+			*field = ref
+		}
+	}
+	setType := func(field *string, mediaType string) {
+		if *field == "" {
+			*field = mediaType
+		}
+	}
+	switch m := media.(type) {
+	case InputPaidMediaPhoto:
+		if err := refs.check("photo", false, false); err != nil {
+			return nil, err
+		}
+		set(&m.Media, refs.media)
+		setType(&m.Type, "photo")
+		return m, nil
+	case InputPaidMediaVideo:
+		if err := refs.check("video", true, true); err != nil {
+			return nil, err
+		}
+		set(&m.Media, refs.media)
+		set(&m.Thumbnail, refs.thumbnail)
+		set(&m.Cover, refs.cover)
+		setType(&m.Type, "video")
+		return m, nil
+	case InputPaidMediaLivePhoto:
+		if err := refs.check("live_photo", false, false); err != nil {
+			return nil, err
+		}
+		set(&m.Media, refs.media)
+		setType(&m.Type, "live_photo")
+		return m, nil
+	case *InputPaidMediaPhoto:
+		if m == nil {
+			return nil, errors.New("media is nil")
+		}
+		return withInputPaidMediaRefs(*m, refs)
+	case *InputPaidMediaVideo:
+		if m == nil {
+			return nil, errors.New("media is nil")
+		}
+		return withInputPaidMediaRefs(*m, refs)
+	case *InputPaidMediaLivePhoto:
+		if m == nil {
+			return nil, errors.New("media is nil")
+		}
+		return withInputPaidMediaRefs(*m, refs)
+	default:
+		return nil, fmt.Errorf("unsupported paid media type %T", media)
+	}
+}
+
+var _ multipartSendable = PaidMediaConfig{}
@@ -0,0 +1,83 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSendPaidMedia_Uploads(t *testing.T) {
+	bot := multipartBot(t, func(r *http.Request) {
+		var media []map[string]any
+		if err := json.Unmarshal([]byte(r.FormValue("media")), &media); err != nil {
+			t.Fatal(err)
+		}
+		want := []map[string]any{
+			{"type": "photo", "media": "attach://file0"},
+			{"type": "video", "media": "existing-id", "thumbnail": "attach://file1", "supports_streaming": true},
+			{"type": "live_photo", "media": "attach://file2"},
+		}
+		if len(media) != len(want) {
+			t.Fatalf("media = %v", media)
+		}
+		for i := range want {
+			for key, value := range want[i] {
+				if media[i][key] != value {
+					t.Errorf("media[%d].%s = %v, want %v", i, key, media[i][key], value)
+				}
+			}
+		}
+		for field, want := range map[string]string{"file0": "photo", "file1": "thumb", "file2": "live"} {
+			if got := formFile(t, r, field); got != want {
+				t.Errorf("%s = %q, want %q", field, got, want)
+			}
+		}
+		if r.FormValue("star_count") != "25" || r.FormValue("payload") != "post:42" || r.FormValue("caption") != "Bonus" {
+			t.Errorf("form = %v", r.MultipartForm.Value)
+		}
+	})
+
+	video := NewPaidMediaVideo(NewInputFileID("existing-id"))
+	video.Media = InputPaidMediaVideo{SupportsStreaming: true}
+	video.Thumbnail = NewInputFileBytes("thumb.jpg", []byte("thumb"))
+	config := NewPaidMedia(42, 25,
+		NewPaidMediaPhoto(NewInputFileBytes("a.jpg", []byte("photo"))),
+		video,
+		NewPaidMediaLivePhoto(NewInputFileBytes("a.mov", []byte("live"))),
+	)
+	config.Payload, config.Caption = "post:42", "Bonus"
+	message, err := bot.Send(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message.MessageID != 7 {
+		t.Errorf("message_id = %d", message.MessageID)
+	}
+}
+
+func TestPaidMediaConfig_Values(t *testing.T) {
+	values, err := NewPaidMedia(42, 10, NewPaidMediaPhoto(NewInputFileID("photo-id"))).Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Get("media") != `[{"type":"photo","media":"photo-id"}]`+"\n" || values.Get("star_count") != "10" {
+		t.Errorf("values = %v", values)
+	}
+
+	tooLongPayload := NewPaidMedia(42, 10, NewPaidMediaPhoto(NewInputFileID("photo-id")))
+	tooLongPayload.Payload = string(make([]byte, 129))
+	for name, config := range map[string]PaidMediaConfig{
+		"no_stars":          NewPaidMedia(42, 0, NewPaidMediaPhoto(NewInputFileID("photo-id"))),
+		"too_many_stars":    NewPaidMedia(42, MaxPaidMediaStarCount+1, NewPaidMediaPhoto(NewInputFileID("photo-id"))),
+		"no_media":          NewPaidMedia(42, 10),
+		"long_payload":      tooLongPayload,
+		"upload_not_values": NewPaidMedia(42, 10, NewPaidMediaPhoto(NewInputFileBytes("a.jpg", []byte("a")))),
+		"media_group_type":  NewPaidMedia(42, 10, PaidMediaItem(NewMediaGroupPhoto(NewInputFileID("photo-id")))),
+		"photo_thumbnail":   NewPaidMedia(42, 10, PaidMediaItem{Media: InputPaidMediaPhoto{}, File: NewInputFileID("photo-id"), Thumbnail: NewInputFileID("thumb-id")}),
+		"live_photo_cover":  NewPaidMedia(42, 10, PaidMediaItem{Media: InputPaidMediaLivePhoto{}, File: NewInputFileID("live-id"), Cover: NewInputFileID("cover-id")}),
+	} {
+		if _, err = config.Values(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package tgbotapi
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 )
@@ -41,20 +42,57 @@ const (
 	PhotoTypeUrl       PhotoType = 3
 )
 
+// photoInputFile returns photo as an InputFile.
+func photoInputFile(photo Photo) InputFile {
+	switch p := photo.(type) {
+	case PhotoUrl:
+		return NewInputFileURL(string(p))
+	case FileID:
+		return NewInputFileID(string(p))
+	case InputFile:
+		return p
+	case nil:
+		return InputFile{err: errors.New("photo is required")}
+	default:
+		return InputFile{err: fmt.Errorf("unsupported photo type %T", photo)}
+	}
+}
+
+// addPhotoReference adds a photo that does not need uploading to values.
+func addPhotoReference(values url.Values, field string, photo Photo) error {
+	file := photoInputFile(photo)
+	if file.err != nil {
+		return file.err
+	}
+	if file.NeedsUpload() {
+		return fmt.Errorf("%s must be uploaded in a multipart request, send the config with BotAPI.Send", field)
+	}
+	values.Add(field, file.reference())
+	return nil
+}
+
 // Values returns url.Values representation of PhotoConfig.
+// A photo to upload is only supported by multipart.
 func (v PhotoConfig) Values() (url.Values, error) {
-	const photoParamName = "photo"
-
-	values, _ := v.BaseChat.Values()
+	values := v.fields()
+	if err := addPhotoReference(values, "photo", v.Photo); err != nil {
+		return values, err
+	}
+	return values, nil
+}
 
-	switch photoType := v.Photo.PhotoType(); photoType {
-	case PhotoTypeUrl:
-		values.Add(photoParamName, string(v.Photo.(PhotoUrl)))
-	case PhotoTypeFileID:
-		values.Add(photoParamName, string(v.Photo.(FileID)))
-	case PhotoTypeInputFile:
-		return values, fmt.Errorf("not implemented yet for photo type: %v", photoType)
+// multipart returns the sendPhoto request, uploading the photo if needed.
+func (v PhotoConfig) multipart() (*multipartRequest, error) {
+	r := newMultipartRequest(paramsFromValues(v.fields()))
+	if err := r.addFile("photo", photoInputFile(v.Photo)); err != nil {
+		return nil, err
 	}
+	return r, nil
+}
+
+// fields returns the request fields except the photo.
+func (v PhotoConfig) fields() url.Values {
+	values, _ := v.BaseChat.Values()
 	if v.Caption != "" {
 		values.Add("caption", v.Caption)
 	}
@@ -64,10 +102,12 @@ func (v PhotoConfig) Values() (url.Values, error) {
 	if v.HasSpoiler {
 		values.Add("has_spoiler", "true")
 	}
-	return values, nil
+	return values
 }
 
 // TelegramMethod returns Telegram API method name for sending Photo.
 func (PhotoConfig) TelegramMethod() string {
 	return "sendPhoto"
 }
+
+var _ multipartSendable = PhotoConfig{}
@@ -1,12 +1,13 @@
 package tgbotapi
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 )
 
-var _ Sendable = (*RichMessageConfig)(nil)
+var _ multipartSendable = (*RichMessageConfig)(nil)
 
 // RichMessageConfig contains information about a sendRichMessage request.
 //
@@ -19,24 +20,54 @@ type RichMessageConfig struct {
 }
 
 // Values returns url.Values representation of RichMessageConfig.
+// Media files to upload are only supported by multipart.
 //
 //goland:noinspection GoMixedReceiverTypes
 func (v RichMessageConfig) Values() (url.Values, error) {
-	if err := v.RichMessage.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid rich message: %w", err)
+	r, err := v.multipart()
+	if err != nil {
+		return nil, err
 	}
+	if len(r.parts) > 0 {
+		return nil, errors.New("rich message media must be uploaded in a multipart request, send the config with BotAPI.Send")
+	}
+	return r.values(), nil
+}
+
+// multipart returns the sendRichMessage request with the media files to upload.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v RichMessageConfig) multipart() (*multipartRequest, error) {
 	values, err := v.BaseChat.Values()
 	if err != nil {
-		return values, err
+		return nil, err
 	}
-
-	if b, err := encodeToJson(v.RichMessage); err != nil {
-		return values, fmt.Errorf("failed to marshal rich message as JSON: %w", err)
-	} else {
-		values.Add("rich_message", string(b))
+	r := newMultipartRequest(paramsFromValues(values))
+
+	message := v.RichMessage
+	if len(message.Media) > 0 {
+		message.Media = make([]InputRichMessageMedia, len(v.RichMessage.Media))
+		for i, media := range v.RichMessage.Media {
+			if !media.File.IsZero() {
+				var ref string
+				if ref, err = r.attach(media.File); err != nil {
+					return nil, fmt.Errorf("rich message media %q: %w", media.ID, err)
+				}
+				if media.Media, _, err = withInputMediaRefs(media.Media, inputMediaRefs{media: ref}); err != nil {
+					return nil, fmt.Errorf("rich message media %q: %w", media.ID, err)
+				}
+			}
+			message.Media[i] = media
+		}
 	}
 
-	return values, nil
+	if err = message.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rich message: %w", err)
+	}
+	if err = r.addJSON("rich_message", message); err != nil {
+		return nil, fmt.Errorf("failed to marshal rich message as JSON: %w", err)
+	}
+	return r, nil
 }
 
 // TelegramMethod returns Telegram API method name for sending a RichMessage.
@@ -0,0 +1,214 @@
+package tgbotapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ErrReactionNotAvailable is returned by SetMessageReactionConfig.ValidateForChat
+// for a reaction that is not allowed in the chat.
+var ErrReactionNotAvailable = errors.New("reaction is not available in the chat")
+
+// SetMessageReactionConfig contains information about a setMessageReaction
+// request. Bots can't use paid reactions, and custom emoji reactions only if
+// they are already present on the message or allowed by chat administrators.
+//
+// https://core.telegram.org/bots/api#setmessagereaction
+type SetMessageReactionConfig struct {
+	ChatConfig
+
+	// Identifier of the target message. If the message belongs to a media group, the reaction is set to the first non-deleted message in the group instead
+	MessageID int
+
+	// New list of reaction types to set on the message. Pass none to remove the reactions of the bot
+	Reaction []ReactionType
+
+	// Pass True to set the reaction with a big animation
+	IsBig bool
+}
+
+// NewMessageReaction returns a config setting reactions on a message,
+// e.g. NewMessageReaction(chatID, messageID, NewReactionEmoji("👍")).
+func NewMessageReaction(chatID int64, messageID int, reaction ...ReactionType) SetMessageReactionConfig {
+	return SetMessageReactionConfig{ChatConfig: ChatConfig{ChatID: chatID}, MessageID: messageID, Reaction: reaction}
+}
+
+// Values returns url.Values representation of SetMessageReactionConfig.
+func (c SetMessageReactionConfig) Values() (url.Values, error) {
+	if c.MessageID == 0 {
+		return nil, errors.New("message_id is required")
+	}
+	for _, reaction := range c.Reaction {
+		if err := reaction.Validate(); err != nil {
+			return nil, err
+		}
+		if reaction.Type == ReactionTypePaid {
+			return nil, errors.New("bots can't set paid reactions")
+		}
+	}
+	values := c.ChatConfig.values()
+	values.Add("message_id", strconv.Itoa(c.MessageID))
+	if len(c.Reaction) > 0 {
+		data, err := json.Marshal(c.Reaction)
+		if err != nil {
+			return nil, err
+		}
+		values.Add("reaction", string(data))
+	}
+	if c.IsBig {
+		values.Add("is_big", "true")
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for setting message reactions.
+func (SetMessageReactionConfig) TelegramMethod() string {
+	return "setMessageReaction"
+}
+
+// ValidateForChat returns an error wrapping ErrReactionNotAvailable if a
+// reaction is not in chat.AvailableReactions or there are more reactions
+// than chat.MaxReactionCount. The chat must be the one returned by getChat.
+func (c SetMessageReactionConfig) ValidateForChat(chat Chat) error {
+	if chat.MaxReactionCount > 0 && len(c.Reaction) > chat.MaxReactionCount {
+		return fmt.Errorf("%w: %d reactions, the maximum is %d", ErrReactionNotAvailable, len(c.Reaction), chat.MaxReactionCount)
+	}
+	for _, reaction := range c.Reaction {
+		if !chat.IsReactionAvailable(reaction) {
+			return fmt.Errorf("%w: %s", ErrReactionNotAvailable, reactionName(reaction))
+		}
+	}
+	return nil
+}
+
+func reactionName(reaction ReactionType) string {
+	switch reaction.Type {
+	case ReactionTypeEmoji:
+		return reaction.Emoji
+	case ReactionTypeCustomEmoji:
+		return "custom emoji " + reaction.CustomEmojiID
+	default:
+		return reaction.Type
+	}
+}
+
+// PinChatMessageConfig contains information about a pinChatMessage request.
+// The bot must be an administrator with the can_pin_messages right in a
+// supergroup or can_edit_messages right in a channel.
+//
+// https://core.telegram.org/bots/api#pinchatmessage
+type PinChatMessageConfig struct {
+	ChatConfig
+
+	// Unique identifier of the business connection on behalf of which the message will be pinned
+	BusinessConnectionID string
+
+	// Identifier of a message to pin
+	MessageID int
+
+	// Pass True if it is not necessary to send a notification to all chat members about the new pinned message
+	DisableNotification bool
+}
+
+// Values returns url.Values representation of PinChatMessageConfig.
+func (c PinChatMessageConfig) Values() (url.Values, error) {
+	if c.MessageID == 0 {
+		return nil, errors.New("message_id is required")
+	}
+	values := c.ChatConfig.values()
+	if c.BusinessConnectionID != "" {
+		values.Add("business_connection_id", c.BusinessConnectionID)
+	}
+	values.Add("message_id", strconv.Itoa(c.MessageID))
+	if c.DisableNotification {
+		values.Add("disable_notification", "true")
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for pinning a message.
+func (PinChatMessageConfig) TelegramMethod() string {
+	return "pinChatMessage"
+}
+
+// UnpinChatMessageConfig contains information about an unpinChatMessage request.
+//
+// https://core.telegram.org/bots/api#unpinchatmessage
+type UnpinChatMessageConfig struct {
+	ChatConfig
+
+	// Unique identifier of the business connection on behalf of which the message will be unpinned
+	BusinessConnectionID string
+
+	// Identifier of the message to unpin. If 0, the most recent pinned message will be unpinned
+	MessageID int
+}
+
+// Values returns url.Values representation of UnpinChatMessageConfig.
+func (c UnpinChatMessageConfig) Values() (url.Values, error) {
+	values := c.ChatConfig.values()
+	if c.BusinessConnectionID != "" {
+		values.Add("business_connection_id", c.BusinessConnectionID)
+	}
+	if c.MessageID != 0 {
+		values.Add("message_id", strconv.Itoa(c.MessageID))
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for unpinning a message.
+func (UnpinChatMessageConfig) TelegramMethod() string {
+	return "unpinChatMessage"
+}
+
+var (
+	_ Sendable = SetMessageReactionConfig{}
+	_ Sendable = PinChatMessageConfig{}
+	_ Sendable = UnpinChatMessageConfig{}
+)
+
+// SetMessageReaction changes the reactions of the bot on a message.
+func (bot *BotAPI) SetMessageReaction(config SetMessageReactionConfig) (APIResponse, error) {
+	return bot.SetMessageReactionContext(context.Background(), config)
+}
+
+// SetMessageReactionContext is SetMessageReaction using ctx for the HTTP call.
+func (bot *BotAPI) SetMessageReactionContext(ctx context.Context, config SetMessageReactionConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// PinChatMessage adds a message to the list of pinned messages in a chat.
+func (bot *BotAPI) PinChatMessage(config PinChatMessageConfig) (APIResponse, error) {
+	return bot.PinChatMessageContext(context.Background(), config)
+}
+
+// PinChatMessageContext is PinChatMessage using ctx for the HTTP call.
+func (bot *BotAPI) PinChatMessageContext(ctx context.Context, config PinChatMessageConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// UnpinChatMessage removes a message from the list of pinned messages in a chat.
+func (bot *BotAPI) UnpinChatMessage(config UnpinChatMessageConfig) (APIResponse, error) {
+	return bot.UnpinChatMessageContext(context.Background(), config)
+}
+
+// UnpinChatMessageContext is UnpinChatMessage using ctx for the HTTP call.
+func (bot *BotAPI) UnpinChatMessageContext(ctx context.Context, config UnpinChatMessageConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// UnpinAllChatMessages clears the list of pinned messages in a chat.
+//
+// https://core.telegram.org/bots/api#unpinallchatmessages
+func (bot *BotAPI) UnpinAllChatMessages(config ChatConfig) (APIResponse, error) {
+	return bot.UnpinAllChatMessagesContext(context.Background(), config)
+}
+
+// UnpinAllChatMessagesContext is UnpinAllChatMessages using ctx for the HTTP call.
+func (bot *BotAPI) UnpinAllChatMessagesContext(ctx context.Context, config ChatConfig) (APIResponse, error) {
+	return bot.MakeRequestContext(ctx, "unpinAllChatMessages", config.values())
+}
@@ -0,0 +1,72 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestSetMessageReactionConfig_Values(t *testing.T) {
+	values, err := NewMessageReaction(1, 7, NewReactionEmoji("👍")).Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := values.Get("reaction"); got != `[{"type":"emoji","emoji":"👍"}]` {
+		t.Errorf("reaction = %s", got)
+	}
+
+	values, err = NewMessageReaction(1, 7).Values()
+	if err != nil || values.Has("reaction") {
+		t.Errorf("removing reactions: values = %v, err = %v", values, err)
+	}
+
+	for name, config := range map[string]SetMessageReactionConfig{
+		"paid":         NewMessageReaction(1, 7, NewReactionPaid()),
+		"empty_emoji":  NewMessageReaction(1, 7, ReactionType{Type: ReactionTypeEmoji}),
+		"unknown_type": NewMessageReaction(1, 7, ReactionType{Type: "sticker"}),
+		"no_message":   NewMessageReaction(1, 0, NewReactionEmoji("👍")),
+	} {
+		if _, err = config.Values(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSetMessageReactionConfig_ValidateForChat(t *testing.T) {
+	var chat Chat
+	if err := json.Unmarshal([]byte(`{"id":1,"type":"supergroup","max_reaction_count":1,
+		"available_reactions":[{"type":"emoji","emoji":"👍"},{"type":"custom_emoji","custom_emoji_id":"42"}]}`), &chat); err != nil {
+		t.Fatal(err)
+	}
+	for _, reaction := range []ReactionType{NewReactionEmoji("👍"), NewReactionCustomEmoji("42")} {
+		if err := NewMessageReaction(1, 7, reaction).ValidateForChat(chat); err != nil {
+			t.Errorf("%+v: %v", reaction, err)
+		}
+	}
+	for name, config := range map[string]SetMessageReactionConfig{
+		"not_available": NewMessageReaction(1, 7, NewReactionEmoji("🔥")),
+		"too_many":      NewMessageReaction(1, 7, NewReactionEmoji("👍"), NewReactionCustomEmoji("42")),
+	} {
+		if err := config.ValidateForChat(chat); !errors.Is(err, ErrReactionNotAvailable) {
+			t.Errorf("%s: err = %v, want ErrReactionNotAvailable", name, err)
+		}
+	}
+
+	if err := NewMessageReaction(1, 7, NewReactionEmoji("🔥")).ValidateForChat(Chat{ID: 1}); err != nil {
+		t.Errorf("all emoji reactions are allowed without available_reactions: %v", err)
+	}
+	if err := NewMessageReaction(1, 7, NewReactionEmoji("🔥")).ValidateForChat(Chat{ID: 1, AvailableReactions: []ReactionType{}}); err == nil {
+		t.Error("no reactions are allowed with empty available_reactions")
+	}
+}
+
+func TestPinChatMessageConfigs_Values(t *testing.T) {
+	values, err := PinChatMessageConfig{ChatConfig: ChatConfig{ChatID: -100}, MessageID: 7, DisableNotification: true}.Values()
+	if err != nil || values.Get("message_id") != "7" || values.Get("disable_notification") != "true" {
+		t.Errorf("pin: values = %v, err = %v", values, err)
+	}
+	values, err = UnpinChatMessageConfig{ChatConfig: ChatConfig{ChatID: -100}}.Values()
+	if err != nil || values.Has("message_id") {
+		t.Errorf("unpin most recent: values = %v, err = %v", values, err)
+	}
+}
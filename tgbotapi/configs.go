@@ -13,10 +13,11 @@ import (
 
 // Telegram constants
 const (
-	// APIEndpoint is the endpoint for all API methods,
-	// with formatting for Sprintf.
+	// APIEndpoint is the endpoint for all API methods of the cloud Bot API
+	// server, with formatting for Sprintf. See BotAPI.BaseURL.
 	APIEndpoint = "https://api.telegram.org/bot%s/%s"
-	// FileEndpoint is the endpoint for downloading a file from Telegram.
+	// FileEndpoint is the endpoint for downloading a file from the cloud Bot
+	// API server. See BotAPI.FileBaseURL.
 	FileEndpoint = "https://api.telegram.org/file/bot%s/%s"
 )
 
@@ -54,8 +55,13 @@ const (
 //)
 //var ErrAPIForbidden = errors.New("forbidden")  // happens when a token is bad or user deleted chat
 
-// ErrAPIForbidden is for 'forbidden' API response
+// ErrAPIForbidden is for 'forbidden' API response.
+//
+// It unwraps to the Telegram provider error when the response body could be
+// decoded, so errors.Is(err, ErrBotBlocked) and TelegramProviderErrorDetailsFrom
+// work for forbidden responses too.
 type ErrAPIForbidden struct {
+	err error
 }
 
 // Error implements error interface
@@ -72,6 +78,13 @@ func (err ErrAPIForbidden) IsForbidden() bool {
 	return true
 }
 
+// Unwrap returns the underlying Telegram provider error, if any.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (err ErrAPIForbidden) Unwrap() error {
+	return err.err
+}
+
 // Constant values for ParseMode in MessageConfig
 const (
 	// ModeMarkdown indicates markdown mode
@@ -105,7 +118,8 @@ type Fileable interface {
 	Sendable
 	params() (map[string]string, error)
 	name() string
-	getFile() interface{}
+	addFiles(r *multipartRequest, field string) error
+	attachFiles(r *multipartRequest) error
 	useExistingFile() bool
 }
 
@@ -229,11 +243,16 @@ func (j BaseChat) Values() (url.Values, error) {
 // BaseFile is a base type for all file config types.
 type BaseFile struct {
 	BaseChat
+
+	// File is the file to upload: a string path, FileBytes, FileReader or InputFile.
 	File        interface{}
 	FileID      string
 	UseExisting bool
 	MimeType    string
 	FileSize    int
+
+	// Thumbnail is an optional thumbnail to send with the file.
+	Thumbnail InputFile
 }
 
 // params returns a map[string]string representation of BaseFile.
@@ -306,11 +325,26 @@ func (file BaseFile) params() (map[string]string, error) {
 	return params, nil
 }
 
-// getFile returns the file.
+// addFiles adds the file as field and the thumbnail, if any, to r.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (file BaseFile) addFiles(r *multipartRequest, field string) error {
+	f, err := inputFileFrom(file.File)
+	if err != nil {
+		return err
+	}
+	if err = r.addFile(field, f); err != nil {
+		return err
+	}
+	return r.addFile("thumbnail", file.Thumbnail)
+}
+
+// attachFiles sets the thumbnail, if any, of an existing file on r, adding
+// a part to upload when it is a new file.
 //
 //goland:noinspection GoMixedReceiverTypes
-func (file BaseFile) getFile() interface{} {
-	return file.File
+func (file BaseFile) attachFiles(r *multipartRequest) error {
+	return r.attachAs("thumbnail", file.Thumbnail)
 }
 
 // useExistingFile returns if the BaseFile has already been uploaded.
@@ -476,6 +510,7 @@ func (j AudioConfig) Values() (url.Values, error) {
 	values, _ := j.BaseChat.Values()
 
 	values.Add(j.name(), j.FileID)
+	addFileReference(values, "thumbnail", j.Thumbnail)
 	if j.Duration != 0 {
 		values.Add("duration", strconv.Itoa(j.Duration))
 	}
@@ -536,6 +571,7 @@ func (v DocumentConfig) Values() (url.Values, error) {
 	values, _ := v.BaseChat.Values()
 
 	values.Add(v.name(), v.FileID)
+	addFileReference(values, "thumbnail", v.Thumbnail)
 
 	return values, nil
 }
@@ -607,6 +643,9 @@ type VideoConfig struct {
 	BaseFile
 	Duration int
 	Caption  string
+
+	// Cover is an optional cover for the video in the message.
+	Cover InputFile
 }
 
 // Values returns a url.Values representation of VideoConfig.
@@ -616,6 +655,8 @@ func (v VideoConfig) Values() (url.Values, error) {
 	values, _ := v.BaseChat.Values()
 
 	values.Add(v.name(), v.FileID)
+	addFileReference(values, "thumbnail", v.Thumbnail)
+	addFileReference(values, "cover", v.Cover)
 	if v.Duration != 0 {
 		values.Add("duration", strconv.Itoa(v.Duration))
 	}
@@ -635,6 +676,26 @@ func (v VideoConfig) params() (map[string]string, error) {
 	return params, nil
 }
 
+// addFiles adds the video, its thumbnail and cover to r.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v VideoConfig) addFiles(r *multipartRequest, field string) error {
+	if err := v.BaseFile.addFiles(r, field); err != nil {
+		return err
+	}
+	return r.addFile("cover", v.Cover)
+}
+
+// attachFiles sets the thumbnail and cover of an existing video on r.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v VideoConfig) attachFiles(r *multipartRequest) error {
+	if err := v.BaseFile.attachFiles(r); err != nil {
+		return err
+	}
+	return r.attachAs("cover", v.Cover)
+}
+
 // name returns the field name for the Video.
 //
 //goland:noinspection GoMixedReceiverTypes
@@ -930,6 +991,10 @@ type UpdateConfig struct {
 	Offset  int
 	Limit   int
 	Timeout int
+
+	// AllowedUpdates lists the update types to receive, e.g. ["message", "callback_query"].
+	// If empty, the previous setting is used.
+	AllowedUpdates []string
 }
 
 // WebhookConfig contains information about a SetWebhook request.
@@ -1016,12 +1081,11 @@ type FileBytes struct {
 }
 
 // FileReader contains information about a reader to upload as a File.
-// If Size is -1, it will read the entire Reader into memory to
-// calculate a Size.
+// The Reader is streamed, so its size does not need to be known.
 type FileReader struct {
 	Name   string
 	Reader io.Reader
-	Size   int64
+	Size   int64 // optional, not needed for streaming
 }
 
 // AnswerCallbackQueryConfig contains information on making a CallbackQuery response.
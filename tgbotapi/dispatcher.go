@@ -0,0 +1,346 @@
+package tgbotapi
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/strongo/logus"
+)
+
+// HandlerFunc handles an update routed by a Dispatcher.
+type HandlerFunc func(ctx context.Context, bot *BotAPI, update Update) error
+
+// Middleware wraps a HandlerFunc, e.g. to log, recover from panics or
+// authorize updates before they reach the handler.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// MessageHandler handles a message routed by a Dispatcher.
+type MessageHandler func(ctx context.Context, bot *BotAPI, message *Message) error
+
+// Chain combines middlewares into one. The first middleware is the outermost.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+type route struct {
+	match  func(bot *BotAPI, update Update) bool
+	handle HandlerFunc
+}
+
+// Dispatcher routes updates to handlers registered with the On* methods.
+//
+// Routes are tried in registration order and the first matching one handles
+// the update. Updates that match no route go to the fallback handler, if any.
+// Routes and middlewares must be registered before updates are dispatched.
+//
+//	d := tgbotapi.NewDispatcher(bot)
+//	d.Use(tgbotapi.RecoverMiddleware(), tgbotapi.LoggingMiddleware())
+//	d.OnCommand("start", handleStart)
+//	d.OnCallbackQuery("vote:", handleVote)
+//	for update := range updater.Start(ctx) {
+//		_ = d.HandleUpdate(ctx, update)
+//	}
+type Dispatcher struct {
+	bot         *BotAPI
+	routes      []route
+	middlewares []Middleware
+	fallback    HandlerFunc
+}
+
+// NewDispatcher creates a Dispatcher that passes bot to the handlers.
+//
+// Commands addressed to another bot, e.g. /start@OtherBot, are matched against
+// bot.Self.UserName, so Self should be populated with GetMe beforehand.
+func NewDispatcher(bot *BotAPI) *Dispatcher {
+	return &Dispatcher{bot: bot}
+}
+
+// Use appends middlewares applied to every dispatched update, including
+// updates handled by the fallback.
+func (d *Dispatcher) Use(middlewares ...Middleware) {
+	d.middlewares = append(d.middlewares, middlewares...)
+}
+
+// Handle registers a handler for updates accepted by match.
+func (d *Dispatcher) Handle(match func(update Update) bool, handler HandlerFunc) {
+	d.routes = append(d.routes, route{
+		match:  func(_ *BotAPI, update Update) bool { return match(update) },
+		handle: handler,
+	})
+}
+
+// Fallback sets the handler for updates that match no route.
+func (d *Dispatcher) Fallback(handler HandlerFunc) {
+	d.fallback = handler
+}
+
+func (d *Dispatcher) onMessage(pick func(update Update) *Message, handler MessageHandler) {
+	d.routes = append(d.routes, route{
+		match: func(_ *BotAPI, update Update) bool { return pick(update) != nil },
+		handle: func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, pick(update))
+		},
+	})
+}
+
+// OnMessage registers a handler for Update.Message.
+func (d *Dispatcher) OnMessage(handler MessageHandler) {
+	d.onMessage(func(update Update) *Message { return update.Message }, handler)
+}
+
+// OnEditedMessage registers a handler for Update.EditedMessage.
+func (d *Dispatcher) OnEditedMessage(handler MessageHandler) {
+	d.onMessage(func(update Update) *Message { return update.EditedMessage }, handler)
+}
+
+// OnChannelPost registers a handler for Update.ChannelPost.
+func (d *Dispatcher) OnChannelPost(handler MessageHandler) {
+	d.onMessage(func(update Update) *Message { return update.ChannelPost }, handler)
+}
+
+// OnEditedChannelPost registers a handler for Update.EditedChannelPost.
+func (d *Dispatcher) OnEditedChannelPost(handler MessageHandler) {
+	d.onMessage(func(update Update) *Message { return update.EditedChannelPost }, handler)
+}
+
+// OnBusinessMessage registers a handler for Update.BusinessMessage.
+func (d *Dispatcher) OnBusinessMessage(handler MessageHandler) {
+	d.onMessage(func(update Update) *Message { return update.BusinessMessage }, handler)
+}
+
+// OnGuestMessage registers a handler for Update.GuestMessage.
+func (d *Dispatcher) OnGuestMessage(handler MessageHandler) {
+	d.onMessage(func(update Update) *Message { return update.GuestMessage }, handler)
+}
+
+// OnCommand registers a handler for an Update.Message with the given command,
+// with or without the leading slash. Commands explicitly addressed to another
+// bot are not matched.
+func (d *Dispatcher) OnCommand(command string, handler MessageHandler) {
+	command = strings.TrimPrefix(command, "/")
+	d.routes = append(d.routes, route{
+		match: func(bot *BotAPI, update Update) bool {
+			return update.Message != nil && update.Message.Command() == command && isCommandForBot(bot, update.Message)
+		},
+		handle: func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.Message)
+		},
+	})
+}
+
+// isCommandForBot reports whether the message's command has no @mention or
+// mentions the bot itself. Any mention is accepted if bot.Self is not known.
+func isCommandForBot(bot *BotAPI, m *Message) bool {
+	command := strings.SplitN(m.Text, " ", 2)[0]
+	i := strings.Index(command, "@")
+	if i == -1 || bot == nil || bot.Self.UserName == "" {
+		return true
+	}
+	return strings.EqualFold(command[i+1:], bot.Self.UserName)
+}
+
+// OnCallbackQuery registers a handler for callback queries whose data starts
+// with prefix. An empty prefix matches every callback query.
+func (d *Dispatcher) OnCallbackQuery(prefix string, handler func(ctx context.Context, bot *BotAPI, query *CallbackQuery) error) {
+	d.routes = append(d.routes, route{
+		match: func(_ *BotAPI, update Update) bool {
+			return update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, prefix)
+		},
+		handle: func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.CallbackQuery)
+		},
+	})
+}
+
+// OnInlineQuery registers a handler for Update.InlineQuery.
+func (d *Dispatcher) OnInlineQuery(handler func(ctx context.Context, bot *BotAPI, query *InlineQuery) error) {
+	d.Handle(func(update Update) bool { return update.InlineQuery != nil },
+		func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.InlineQuery)
+		})
+}
+
+// OnChosenInlineResult registers a handler for Update.ChosenInlineResult.
+func (d *Dispatcher) OnChosenInlineResult(handler func(ctx context.Context, bot *BotAPI, result *ChosenInlineResult) error) {
+	d.Handle(func(update Update) bool { return update.ChosenInlineResult != nil },
+		func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.ChosenInlineResult)
+		})
+}
+
+// OnShippingQuery registers a handler for Update.ShippingQuery.
+func (d *Dispatcher) OnShippingQuery(handler func(ctx context.Context, bot *BotAPI, query *ShippingQuery) error) {
+	d.Handle(func(update Update) bool { return update.ShippingQuery != nil },
+		func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.ShippingQuery)
+		})
+}
+
+// OnPreCheckoutQuery registers a handler for Update.PreCheckoutQuery.
+func (d *Dispatcher) OnPreCheckoutQuery(handler func(ctx context.Context, bot *BotAPI, query *PreCheckoutQuery) error) {
+	d.Handle(func(update Update) bool { return update.PreCheckoutQuery != nil },
+		func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.PreCheckoutQuery)
+		})
+}
+
+// OnPurchasedPaidMedia registers a handler for Update.PurchasedPaidMedia.
+func (d *Dispatcher) OnPurchasedPaidMedia(handler func(ctx context.Context, bot *BotAPI, purchase *PaidMediaPurchased) error) {
+	d.Handle(func(update Update) bool { return update.PurchasedPaidMedia != nil },
+		func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.PurchasedPaidMedia)
+		})
+}
+
+// OnPaidMediaPayload registers a handler for Update.PurchasedPaidMedia whose
+// paid media payload, set with PaidMediaConfig.Payload, starts with prefix.
+func (d *Dispatcher) OnPaidMediaPayload(prefix string, handler func(ctx context.Context, bot *BotAPI, purchase *PaidMediaPurchased) error) {
+	d.Handle(func(update Update) bool {
+		return update.PurchasedPaidMedia != nil && strings.HasPrefix(update.PurchasedPaidMedia.PaidMediaPayload, prefix)
+	}, func(ctx context.Context, bot *BotAPI, update Update) error {
+		return handler(ctx, bot, update.PurchasedPaidMedia)
+	})
+}
+
+// OnMyChatMember registers a handler for Update.MyChatMember.
+func (d *Dispatcher) OnMyChatMember(handler func(ctx context.Context, bot *BotAPI, updated *ChatMemberUpdated) error) {
+	d.Handle(func(update Update) bool { return update.MyChatMember != nil },
+		func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.MyChatMember)
+		})
+}
+
+// OnChatMember registers a handler for Update.ChatMember.
+func (d *Dispatcher) OnChatMember(handler func(ctx context.Context, bot *BotAPI, updated *ChatMemberUpdated) error) {
+	d.Handle(func(update Update) bool { return update.ChatMember != nil },
+		func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.ChatMember)
+		})
+}
+
+// OnChatJoinRequest registers a handler for Update.ChatJoinRequest.
+func (d *Dispatcher) OnChatJoinRequest(handler func(ctx context.Context, bot *BotAPI, request *ChatJoinRequest) error) {
+	d.Handle(func(update Update) bool { return update.ChatJoinRequest != nil },
+		func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.ChatJoinRequest)
+		})
+}
+
+// OnPollAnswer registers a handler for Update.PollAnswer.
+func (d *Dispatcher) OnPollAnswer(handler func(ctx context.Context, bot *BotAPI, answer *PollAnswer) error) {
+	d.Handle(func(update Update) bool { return update.PollAnswer != nil },
+		func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.PollAnswer)
+		})
+}
+
+// OnSubscription registers a handler for Update.Subscription.
+func (d *Dispatcher) OnSubscription(handler func(ctx context.Context, bot *BotAPI, subscription *BotSubscriptionUpdated) error) {
+	d.Handle(func(update Update) bool { return update.Subscription != nil },
+		func(ctx context.Context, bot *BotAPI, update Update) error {
+			return handler(ctx, bot, update.Subscription)
+		})
+}
+
+// HandleUpdate routes update to the first matching handler through the
+// middlewares. It returns nil if no route matches and no fallback is set.
+func (d *Dispatcher) HandleUpdate(ctx context.Context, update Update) error {
+	handler := d.fallback
+	for _, r := range d.routes {
+		if r.match(d.bot, update) {
+			handler = r.handle
+			break
+		}
+	}
+	if handler == nil {
+		return nil
+	}
+	return Chain(d.middlewares...)(handler)(ctx, d.bot, update)
+}
+
+// HandleWebhookUpdate is a WebhookUpdateHandler that dispatches the update:
+//
+//	tgbotapi.NewWebhookHandler(secretToken, d.HandleWebhookUpdate)
+func (d *Dispatcher) HandleWebhookUpdate(ctx context.Context, update Update) (Sendable, error) {
+	return nil, d.HandleUpdate(ctx, update)
+}
+
+// Run dispatches updates one by one until the channel is closed or ctx is
+// cancelled. Errors returned by handlers are passed to onError, if not nil.
+func (d *Dispatcher) Run(ctx context.Context, updates <-chan Update, onError func(update Update, err error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			if err := d.HandleUpdate(ctx, update); err != nil && onError != nil {
+				onError(update, err)
+			}
+		}
+	}
+}
+
+// RecoverMiddleware converts a panic in the next handler into an error and
+// logs the stack trace.
+func RecoverMiddleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, bot *BotAPI, update Update) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					logus.Errorf(ctx, "Panic while handling Telegram update: update_id=%d, panic=%v\n%s", update.UpdateID, r, debug.Stack())
+					err = fmt.Errorf("panic while handling update %d: %v", update.UpdateID, r)
+				}
+			}()
+			return next(ctx, bot, update)
+		}
+	}
+}
+
+// LoggingMiddleware logs every update with the handling duration and the
+// type of a returned error.
+func LoggingMiddleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, bot *BotAPI, update Update) error {
+			started := time.Now()
+			err := next(ctx, bot, update)
+			if err != nil {
+				logus.Warningf(ctx, "Telegram update handler failed: update_id=%d, kind=%s, duration=%v, error_type=%T", update.UpdateID, update.Kind(), time.Since(started), err)
+			} else {
+				logus.Debugf(ctx, "Telegram update handled: update_id=%d, kind=%s, duration=%v", update.UpdateID, update.Kind(), time.Since(started))
+			}
+			return err
+		}
+	}
+}
+
+// AllowUsersMiddleware passes only updates sent by the given users to the next
+// handler and silently drops the rest.
+func AllowUsersMiddleware(userIDs ...int64) Middleware {
+	allowed := make(map[int64]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		allowed[id] = struct{}{}
+	}
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, bot *BotAPI, update Update) error {
+			from := update.From()
+			if from == nil {
+				return nil
+			}
+			if _, ok := allowed[from.ID]; !ok {
+				return nil
+			}
+			return next(ctx, bot, update)
+		}
+	}
+}
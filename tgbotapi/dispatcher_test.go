@@ -0,0 +1,130 @@
+package tgbotapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func commandUpdate(text string) Update {
+	return Update{Message: &Message{Text: text, From: &User{ID: 1}, Chat: &Chat{ID: 1}}}
+}
+
+func TestDispatcher_Routes(t *testing.T) {
+	bot := NewBotAPI("123456:TOKEN")
+	bot.Self.UserName = "MyBot"
+
+	var handled string
+	d := NewDispatcher(bot)
+	d.OnCommand("/start", func(_ context.Context, _ *BotAPI, m *Message) error {
+		handled = "start:" + m.CommandArguments()
+		return nil
+	})
+	d.OnMessage(func(context.Context, *BotAPI, *Message) error {
+		handled = "message"
+		return nil
+	})
+	d.OnCallbackQuery("vote:", func(_ context.Context, _ *BotAPI, q *CallbackQuery) error {
+		handled = "vote " + q.Data
+		return nil
+	})
+	d.OnSubscription(func(context.Context, *BotAPI, *BotSubscriptionUpdated) error {
+		handled = "subscription"
+		return nil
+	})
+	d.OnPaidMediaPayload("post:", func(_ context.Context, _ *BotAPI, p *PaidMediaPurchased) error {
+		handled = "unlock " + p.PaidMediaPayload
+		return nil
+	})
+	d.Fallback(func(context.Context, *BotAPI, Update) error {
+		handled = "fallback"
+		return nil
+	})
+
+	tests := []struct {
+		name   string
+		update Update
+		want   string
+	}{
+		{"command", commandUpdate("/start ref"), "start:ref"},
+		{"command for this bot", commandUpdate("/start@mybot ref"), "start:ref"},
+		{"command for other bot", commandUpdate("/start@OtherBot"), "message"},
+		{"other command", commandUpdate("/help"), "message"},
+		{"callback prefix", Update{CallbackQuery: &CallbackQuery{Data: "vote:1"}}, "vote vote:1"},
+		{"callback other prefix", Update{CallbackQuery: &CallbackQuery{Data: "menu"}}, "fallback"},
+		{"subscription", Update{Subscription: &BotSubscriptionUpdated{State: BotSubscriptionStateActive}}, "subscription"},
+		{"paid media payload", Update{PurchasedPaidMedia: &PaidMediaPurchased{PaidMediaPayload: "post:42"}}, "unlock post:42"},
+		{"paid media other payload", Update{PurchasedPaidMedia: &PaidMediaPurchased{PaidMediaPayload: "album:1"}}, "fallback"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = ""
+			if err := d.HandleUpdate(context.Background(), tt.update); err != nil {
+				t.Fatal(err)
+			}
+			if handled != tt.want {
+				t.Fatalf("handled = %q, want %q", handled, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatcher_Middleware(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, bot *BotAPI, update Update) error {
+				calls = append(calls, name)
+				return next(ctx, bot, update)
+			}
+		}
+	}
+	d := NewDispatcher(nil)
+	d.Use(RecoverMiddleware(), trace("outer"), trace("inner"), AllowUsersMiddleware(1))
+	d.OnMessage(func(_ context.Context, _ *BotAPI, m *Message) error {
+		calls = append(calls, "handler")
+		if m.Text == "panic" {
+			panic("boom")
+		}
+		return nil
+	})
+
+	if err := d.HandleUpdate(context.Background(), commandUpdate("hi")); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(calls); got != 3 || calls[0] != "outer" || calls[1] != "inner" || calls[2] != "handler" {
+		t.Fatalf("calls = %v", calls)
+	}
+
+	calls = nil
+	stranger := Update{Message: &Message{Text: "hi", From: &User{ID: 2}}}
+	if err := d.HandleUpdate(context.Background(), stranger); err != nil || len(calls) != 2 {
+		t.Fatalf("unauthorized update: err=%v, calls=%v", err, calls)
+	}
+
+	if err := d.HandleUpdate(context.Background(), commandUpdate("panic")); err == nil {
+		t.Fatal("expected panic to be converted into an error")
+	}
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	d := NewDispatcher(nil)
+	errBoom := errors.New("boom")
+	d.OnMessage(func(context.Context, *BotAPI, *Message) error { return errBoom })
+
+	updates := make(chan Update, 2)
+	updates <- commandUpdate("a")
+	updates <- Update{UpdateID: 2}
+	close(updates)
+
+	var failed []int
+	d.Run(context.Background(), updates, func(update Update, err error) {
+		if !errors.Is(err, errBoom) {
+			t.Errorf("err = %v", err)
+		}
+		failed = append(failed, update.UpdateID)
+	})
+	if len(failed) != 1 {
+		t.Fatalf("onError called %d times, want 1", len(failed))
+	}
+}
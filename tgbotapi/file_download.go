@@ -0,0 +1,262 @@
+package tgbotapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/strongo/logus"
+)
+
+var (
+	// ErrFileTooLarge happens when a file is larger than BotAPI.MaxDownloadSize.
+	ErrFileTooLarge = errors.New("file is too large")
+
+	// ErrFileSizeMismatch happens when a downloaded file is not of the size
+	// reported by getFile.
+	ErrFileSizeMismatch = errors.New("downloaded file size does not match file_size")
+
+	// ErrNoFilePath happens when getFile returned no file_path, e.g. for
+	// files over 20 MB on the cloud Bot API server.
+	ErrNoFilePath = errors.New("file has no file_path")
+
+	// ErrContentRangeMismatch happens when a resumed download is answered
+	// with a part of the file that doesn't start at the requested offset.
+	ErrContentRangeMismatch = errors.New("file server returned a range other than requested")
+)
+
+// fileDownloadError preserves the cause of a failed download for
+// errors.Is/errors.As without exposing the token-bearing file URL or a local
+// Bot API server path, which contains the token as well.
+type fileDownloadError struct {
+	fileID string
+	err    error
+}
+
+func (e fileDownloadError) Error() string {
+	return fmt.Sprintf("failed to download Telegram file %q", e.fileID)
+}
+
+func (e fileDownloadError) Unwrap() error {
+	return e.err
+}
+
+// fileStatusError is returned when the file server responds with a status
+// other than 200 or 206.
+type fileStatusError struct {
+	statusCode int
+}
+
+func (e fileStatusError) Error() string {
+	return fmt.Sprintf("Telegram file server returned %s", http.StatusText(e.statusCode))
+}
+
+// DownloadFile gets a file with getFile and writes it to w.
+//
+// It streams the file, verifies its size against File.FileSize and limits it
+// to BotAPI.MaxDownloadSize. Errors never contain the bot token.
+func (bot *BotAPI) DownloadFile(fileID string, w io.Writer) (File, error) {
+	return bot.DownloadFileFromContext(context.Background(), fileID, 0, w)
+}
+
+// DownloadFileContext is DownloadFile using ctx for the HTTP calls.
+func (bot *BotAPI) DownloadFileContext(ctx context.Context, fileID string, w io.Writer) (File, error) {
+	return bot.DownloadFileFromContext(ctx, fileID, 0, w)
+}
+
+// DownloadFileFrom resumes a download, writing the file to w starting at
+// offset, e.g. the size of a partially downloaded file.
+func (bot *BotAPI) DownloadFileFrom(fileID string, offset int64, w io.Writer) (File, error) {
+	return bot.DownloadFileFromContext(context.Background(), fileID, offset, w)
+}
+
+// DownloadFileFromContext is DownloadFileFrom using ctx for the HTTP calls.
+func (bot *BotAPI) DownloadFileFromContext(ctx context.Context, fileID string, offset int64, w io.Writer) (File, error) {
+	file, err := bot.GetFileContext(ctx, FileConfig{fileID})
+	if err != nil {
+		return file, err
+	}
+
+	r, err := bot.OpenFileContext(ctx, file, offset)
+	if err != nil {
+		return file, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	written, err := io.Copy(w, r)
+	if err != nil {
+		return file, err
+	}
+	if bot.c != nil {
+		logus.Debugf(bot.c, "Telegram file downloaded: offset=%d, bytes=%d", offset, written)
+	}
+	return file, nil
+}
+
+// OpenFile opens a file returned by GetFile for reading, starting at offset.
+// A non-zero offset is requested with an HTTP Range header.
+//
+// A file_path that is an absolute path, as returned by a local Bot API
+// server, is read from the local file system.
+//
+// Reads fail with ErrFileTooLarge past BotAPI.MaxDownloadSize and with
+// ErrFileSizeMismatch if the file ends up not of File.FileSize.
+// The caller must close the reader.
+func (bot *BotAPI) OpenFile(file File, offset int64) (io.ReadCloser, error) {
+	return bot.OpenFileContext(context.Background(), file, offset)
+}
+
+// OpenFileContext is OpenFile using ctx for the HTTP call.
+func (bot *BotAPI) OpenFileContext(ctx context.Context, file File, offset int64) (io.ReadCloser, error) {
+	if file.FilePath == "" {
+		return nil, ErrNoFilePath
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("offset must not be negative, got %d", offset)
+	}
+	size := int64(file.FileSize)
+	if bot.MaxDownloadSize > 0 && size > bot.MaxDownloadSize {
+		return nil, ErrFileTooLarge
+	}
+	if size > 0 && offset > size {
+		return nil, fmt.Errorf("offset %d is beyond the file size %d", offset, size)
+	}
+
+	var (
+		body io.ReadCloser
+		err  error
+	)
+	if file.IsLocal() {
+		body, err = openLocalFile(file.FilePath, offset)
+	} else {
+		body, err = bot.openRemoteFile(ctx, file, offset)
+	}
+	if err != nil {
+		return nil, fileDownloadError{fileID: file.FileID, err: err}
+	}
+	return &downloadReader{
+		fileID:   file.FileID,
+		body:     body,
+		read:     offset,
+		size:     size,
+		maxBytes: bot.MaxDownloadSize,
+	}, nil
+}
+
+// openLocalFile opens a file stored by a local Bot API server.
+func openLocalFile(path string, offset int64) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		if _, err = f.Seek(offset, io.SeekStart); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// openRemoteFile requests a file from the Bot API file server. If the server
+// ignores the Range header, the bytes before offset are skipped.
+func (bot *BotAPI) openRemoteFile(ctx context.Context, file File, offset int64) (io.ReadCloser, error) {
+	if offset > 0 && offset == int64(file.FileSize) {
+		return io.NopCloser(http.NoBody), nil
+	}
+
+	fileURL, err := bot.fileURL(file.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	res, err := bot.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusPartialContent && offset > 0:
+		contentRange := res.Header.Get("Content-Range")
+		if start, ok := contentRangeStart(contentRange); !ok || start != offset {
+			_ = res.Body.Close()
+			return nil, fmt.Errorf("%w: requested bytes from %d, got Content-Range %q", ErrContentRangeMismatch, offset, contentRange)
+		}
+		return res.Body, nil
+	case res.StatusCode == http.StatusOK:
+		if offset > 0 {
+			if _, err = io.CopyN(io.Discard, res.Body, offset); err != nil {
+				_ = res.Body.Close()
+				return nil, err
+			}
+		}
+		return res.Body, nil
+	default:
+		_ = res.Body.Close()
+		return nil, fileStatusError{statusCode: res.StatusCode}
+	}
+}
+
+// contentRangeStart returns the first byte position of a Content-Range header
+// of the form "bytes first-last/length".
+func contentRangeStart(contentRange string) (int64, bool) {
+	spec, ok := strings.CutPrefix(contentRange, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	first, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	return start, err == nil
+}
+
+// downloadReader enforces the size limit and verifies the file size while
+// the file is read.
+type downloadReader struct {
+	fileID   string
+	body     io.ReadCloser
+	read     int64 // including the offset
+	size     int64 // 0 if unknown
+	maxBytes int64 // 0 if unlimited
+}
+
+func (r *downloadReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.read += int64(n)
+	if r.maxBytes > 0 && r.read > r.maxBytes {
+		return n, ErrFileTooLarge
+	}
+	if r.size > 0 && r.read > r.size {
+		return n, ErrFileSizeMismatch
+	}
+	switch {
+	case err == io.EOF:
+		if r.size > 0 && r.read != r.size {
+			return n, ErrFileSizeMismatch
+		}
+		return n, io.EOF
+	case err != nil:
+		return n, fileDownloadError{fileID: r.fileID, err: err}
+	}
+	return n, nil
+}
+
+func (r *downloadReader) Close() error {
+	return r.body.Close()
+}
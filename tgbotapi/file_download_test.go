@@ -0,0 +1,173 @@
+package tgbotapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const downloadContent = "0123456789"
+
+// fileServerBot returns a bot answering getFile with filePath and fileSize and
+// serving content from the file server, honoring Range if supportsRange.
+func fileServerBot(token, filePath string, fileSize int, content string, supportsRange bool, ranges *[]string) *BotAPI {
+	return NewBotAPIWithClient(token, &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			res := &http.Response{StatusCode: http.StatusOK, Header: make(http.Header)}
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/getFile"):
+				res.Body = io.NopCloser(strings.NewReader(fmt.Sprintf(
+					`{"ok":true,"result":{"file_id":%q,"file_size":%d,"file_path":%q}}`,
+					r.FormValue("file_id"), fileSize, filePath)))
+			case r.URL.Path == "/file/bot"+token+"/"+filePath:
+				body := content
+				if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+					if ranges != nil {
+						*ranges = append(*ranges, rangeHeader)
+					}
+					if supportsRange {
+						var offset int
+						_, _ = fmt.Sscanf(rangeHeader, "bytes=%d-", &offset)
+						body = content[offset:]
+						res.StatusCode = http.StatusPartialContent
+						res.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(content)-1, len(content)))
+					}
+				}
+				res.Body = io.NopCloser(strings.NewReader(body))
+			default:
+				res.StatusCode = http.StatusNotFound
+				res.Body = io.NopCloser(strings.NewReader("not found"))
+			}
+			return res, nil
+		}),
+	})
+}
+
+func TestDownloadFile(t *testing.T) {
+	bot := fileServerBot("123:TOKEN", "documents/file_1.txt", len(downloadContent), downloadContent, true, nil)
+	var buf bytes.Buffer
+	file, err := bot.DownloadFile("file-id", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != downloadContent || file.FilePath != "documents/file_1.txt" {
+		t.Fatalf("downloaded %q, file = %+v", buf.String(), file)
+	}
+}
+
+func TestDownloadFileFrom_Resumes(t *testing.T) {
+	for _, supportsRange := range []bool{true, false} {
+		var ranges []string
+		bot := fileServerBot("123:TOKEN", "documents/file_1.txt", len(downloadContent), downloadContent, supportsRange, &ranges)
+		var buf bytes.Buffer
+		if _, err := bot.DownloadFileFrom("file-id", 4, &buf); err != nil {
+			t.Fatalf("supportsRange=%v: %v", supportsRange, err)
+		}
+		if buf.String() != downloadContent[4:] || len(ranges) != 1 || ranges[0] != "bytes=4-" {
+			t.Errorf("supportsRange=%v: downloaded %q, ranges = %v", supportsRange, buf.String(), ranges)
+		}
+	}
+}
+
+func TestDownloadFileFrom_RejectsWrongRange(t *testing.T) {
+	for _, contentRange := range []string{"bytes 0-9/10", ""} {
+		bot := NewBotAPIWithClient("123:TOKEN", &http.Client{
+			Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				if strings.HasSuffix(r.URL.Path, "/getFile") {
+					return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(
+						`{"ok":true,"result":{"file_id":"file-id","file_size":10,"file_path":"documents/file_1.txt"}}`))}, nil
+				}
+				res := &http.Response{StatusCode: http.StatusPartialContent, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(downloadContent))}
+				if contentRange != "" {
+					res.Header.Set("Content-Range", contentRange)
+				}
+				return res, nil
+			}),
+		})
+		var buf bytes.Buffer
+		if _, err := bot.DownloadFileFrom("file-id", 4, &buf); !errors.Is(err, ErrContentRangeMismatch) || buf.Len() != 0 {
+			t.Errorf("Content-Range %q: err = %v, wrote %q", contentRange, err, buf.String())
+		}
+	}
+}
+
+func TestDownloadFile_Limits(t *testing.T) {
+	bot := fileServerBot("123:TOKEN", "documents/file_1.txt", 0, downloadContent, true, nil)
+	bot.MaxDownloadSize = 5
+	if _, err := bot.DownloadFile("file-id", io.Discard); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("unknown size over limit: err = %v, want ErrFileTooLarge", err)
+	}
+
+	bot = fileServerBot("123:TOKEN", "documents/file_1.txt", len(downloadContent), downloadContent, true, nil)
+	bot.MaxDownloadSize = 5
+	if _, err := bot.DownloadFile("file-id", io.Discard); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("known size over limit: err = %v, want ErrFileTooLarge", err)
+	}
+
+	bot = fileServerBot("123:TOKEN", "documents/file_1.txt", len(downloadContent)+1, downloadContent, true, nil)
+	if _, err := bot.DownloadFile("file-id", io.Discard); !errors.Is(err, ErrFileSizeMismatch) {
+		t.Errorf("truncated file: err = %v, want ErrFileSizeMismatch", err)
+	}
+
+	bot = fileServerBot("123:TOKEN", "", 0, "", true, nil)
+	if _, err := bot.DownloadFile("file-id", io.Discard); !errors.Is(err, ErrNoFilePath) {
+		t.Errorf("no file_path: err = %v, want ErrNoFilePath", err)
+	}
+}
+
+func TestDownloadFile_ErrorsDoNotExposeToken(t *testing.T) {
+	const token = "123456:PRIVATE-BOT-TOKEN"
+	bot := fileServerBot(token, "documents/missing.txt", 0, "", true, nil)
+	bot.Client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(r.URL.Path, "/getFile") {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(`{"ok":true,"result":{"file_id":"a","file_path":"documents/missing.txt"}}`)),
+			}, nil
+		}
+		return nil, errors.New("dial failed for " + r.URL.String())
+	})
+	_, err := bot.DownloadFile("a", io.Discard)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	assertDoesNotContainPrivateValues(t, err.Error(), token)
+
+	local := filepath.Join(t.TempDir(), token, "missing.txt")
+	_, err = bot.OpenFile(File{FileID: "a", FilePath: local}, 0)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("err = %v, want os.ErrNotExist", err)
+	}
+	assertDoesNotContainPrivateValues(t, err.Error(), token)
+}
+
+func TestOpenFile_LocalServerPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file_1.txt")
+	if err := os.WriteFile(path, []byte(downloadContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	bot := NewBotAPIWithClient("123:TOKEN", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			t.Fatalf("unexpected request to %s", r.URL.Path)
+			return nil, nil
+		}),
+	})
+	r, err := bot.OpenFile(File{FileID: "a", FilePath: path, FileSize: len(downloadContent)}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	data, err := io.ReadAll(r)
+	if err != nil || string(data) != downloadContent[2:] {
+		t.Fatalf("read %q, err = %v", data, err)
+	}
+}
@@ -45,11 +45,19 @@ func NewForward(chatID int64, fromChatID int64, messageID int) *ForwardConfig {
 // NewPhotoUpload creates a new photo uploader.
 //
 // chatID is where to send it, file is a string path to the file,
-// FileReader, or FileBytes.
+// FileReader, FileBytes or InputFile. An unsupported file is reported
+// when the config is sent.
 //
 // Note that you must send animated GIFs as a document.
 func NewPhotoUpload(chatID int64, file interface{}) *PhotoConfig {
-	panic("not implemented yet")
+	photo, err := inputFileFrom(file)
+	if err != nil {
+		photo = InputFile{err: err}
+	}
+	return &PhotoConfig{
+		BaseChat: BaseChat{ChatID: chatID},
+		Photo:    photo,
+	}
 }
 
 // NewPhotoShare shares an existing photo.
@@ -482,3 +490,15 @@ func NewCallbackWithURL(url string) AnswerCallbackQueryConfig {
 		//ShowAlert:       showAlert,
 	}
 }
+
+// NewCopyMessage creates a new copyMessage request.
+//
+// chatID is where to send it, fromChatID is the source chat,
+// and messageID is the ID of the original message.
+func NewCopyMessage(chatID int64, fromChatID int64, messageID int) *CopyMessageConfig {
+	return &CopyMessageConfig{
+		BaseChat:   BaseChat{ChatID: chatID},
+		FromChatID: fromChatID,
+		MessageID:  messageID,
+	}
+}
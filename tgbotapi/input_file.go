@@ -0,0 +1,339 @@
+package tgbotapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+type inputFileKind int
+
+const (
+	inputFileNone inputFileKind = iota
+	inputFileID
+	inputFileURL
+	inputFilePath
+	inputFileBytes
+	inputFileReader
+)
+
+// InputFile is a file to send: a file_id or an HTTP URL that Telegram
+// resolves itself, or a local file, bytes or a reader to upload.
+//
+// The zero value means no file. Create InputFile with NewInputFileID,
+// NewInputFileURL, NewInputFilePath, NewInputFileBytes or NewInputFileReader.
+//
+// https://core.telegram.org/bots/api#inputfile
+type InputFile struct {
+	kind   inputFileKind
+	value  string // file_id, URL or path
+	name   string
+	data   []byte
+	reader io.Reader
+	err    error
+}
+
+var _ Photo = InputFile{}
+
+// NewInputFileID references a file that exists on the Telegram servers.
+func NewInputFileID(fileID string) InputFile {
+	return InputFile{kind: inputFileID, value: fileID}
+}
+
+// NewInputFileURL references a file Telegram should get from the Internet.
+func NewInputFileURL(url string) InputFile {
+	return InputFile{kind: inputFileURL, value: url}
+}
+
+// NewInputFilePath uploads a local file. It is opened when the request is sent.
+func NewInputFilePath(path string) InputFile {
+	return InputFile{kind: inputFilePath, value: path}
+}
+
+// NewInputFileBytes uploads data as a file with the given name.
+func NewInputFileBytes(name string, data []byte) InputFile {
+	return InputFile{kind: inputFileBytes, name: name, data: data}
+}
+
+// NewInputFileReader uploads the contents of reader as a file with the given
+// name. The reader is streamed to Telegram, its size does not need to be known.
+// A nil reader makes the request fail before anything is sent.
+func NewInputFileReader(name string, reader io.Reader) InputFile {
+	if reader == nil {
+		return InputFile{kind: inputFileReader, name: name, err: errors.New("file reader is nil")}
+	}
+	return InputFile{kind: inputFileReader, name: name, reader: reader}
+}
+
+// PhotoType implements Photo.
+func (InputFile) PhotoType() PhotoType {
+	return PhotoTypeInputFile
+}
+
+// IsZero reports whether f holds no file.
+func (f InputFile) IsZero() bool {
+	return f.kind == inputFileNone && f.err == nil
+}
+
+// NeedsUpload reports whether the file has to be uploaded in a multipart request.
+func (f InputFile) NeedsUpload() bool {
+	return f.kind == inputFilePath || f.kind == inputFileBytes || f.kind == inputFileReader
+}
+
+// reference returns the file_id or URL of a file that does not need uploading.
+func (f InputFile) reference() string {
+	return f.value
+}
+
+// part returns the multipart part uploading the file under field.
+func (f InputFile) part(field string) uploadPart {
+	switch f.kind {
+	case inputFilePath:
+		return uploadPart{field: field, name: f.name, path: f.value}
+	case inputFileBytes:
+		return uploadPart{field: field, name: f.name, reader: bytes.NewReader(f.data)}
+	default:
+		return uploadPart{field: field, name: f.name, reader: f.reader}
+	}
+}
+
+// inputFileFrom converts the file types accepted by BaseFile.File and
+// UploadFile into an InputFile: a string path, FileBytes, FileReader, FileID
+// or InputFile.
+func inputFileFrom(file any) (InputFile, error) {
+	switch f := file.(type) {
+	case InputFile:
+		return f, f.err
+	case string:
+		return NewInputFilePath(f), nil
+	case FileBytes:
+		return NewInputFileBytes(f.Name, f.Bytes), nil
+	case FileReader:
+		inputFile := NewInputFileReader(f.Name, f.Reader)
+		return inputFile, inputFile.err
+	case FileID:
+		return NewInputFileID(string(f)), nil
+	default:
+		return InputFile{}, ErrBadFileType
+	}
+}
+
+// multipartRequest collects the fields of a request together with the files
+// to upload. Configs that can carry new uploads describe themselves with it,
+// see multipartSendable.
+type multipartRequest struct {
+	params map[string]string
+	parts  []uploadPart
+}
+
+func newMultipartRequest(params map[string]string) *multipartRequest {
+	if params == nil {
+		params = make(map[string]string)
+	}
+	return &multipartRequest{params: params}
+}
+
+// addFile sets field to the file's reference or uploads the file as field.
+// A zero file is ignored.
+func (r *multipartRequest) addFile(field string, file InputFile) error {
+	if file.err != nil {
+		return file.err
+	}
+	switch {
+	case file.IsZero():
+	case file.NeedsUpload():
+		r.parts = append(r.parts, file.part(field))
+	default:
+		r.params[field] = file.reference()
+	}
+	return nil
+}
+
+// attach returns the value referencing file from a JSON field: its file_id or
+// URL, or attach://<name> of a newly added part. A zero file yields "".
+func (r *multipartRequest) attach(file InputFile) (string, error) {
+	if file.err != nil {
+		return "", file.err
+	}
+	if !file.NeedsUpload() {
+		return file.reference(), nil
+	}
+	field := "file" + strconv.Itoa(len(r.parts))
+	r.parts = append(r.parts, file.part(field))
+	return "attach://" + field, nil
+}
+
+// attachAs sets field to the value attach returns for file. A zero file is
+// ignored.
+func (r *multipartRequest) attachAs(field string, file InputFile) error {
+	ref, err := r.attach(file)
+	if err != nil {
+		return err
+	}
+	if ref != "" {
+		r.params[field] = ref
+	}
+	return nil
+}
+
+// addJSON sets field to v encoded as JSON.
+func (r *multipartRequest) addJSON(field string, v any) error {
+	data, err := encodeToJson(v)
+	if err != nil {
+		return err
+	}
+	r.params[field] = string(data)
+	return nil
+}
+
+// values returns the fields of a request without uploads.
+func (r *multipartRequest) values() url.Values {
+	values := make(url.Values, len(r.params))
+	for key, value := range r.params {
+		values.Set(key, value)
+	}
+	return values
+}
+
+// addFileReference sets field to the file_id or URL of file. Files to upload
+// are left to the multipart request.
+func addFileReference(values url.Values, field string, file InputFile) {
+	if !file.NeedsUpload() && file.reference() != "" {
+		values.Set(field, file.reference())
+	}
+}
+
+// paramsFromValues converts url.Values built by Values() into request params.
+func paramsFromValues(values url.Values) map[string]string {
+	params := make(map[string]string, len(values))
+	for key := range values {
+		params[key] = values.Get(key)
+	}
+	return params
+}
+
+// multipartSendable is a Sendable that may carry files to upload. SendContext
+// uses a multipart request for it when any file needs uploading.
+type multipartSendable interface {
+	Sendable
+	multipart() (*multipartRequest, error)
+}
+
+// inputMediaRefs holds attach:// or file references to set on an InputMedia*
+// value. Empty references leave the corresponding field unchanged.
+type inputMediaRefs struct {
+	media, thumbnail, cover string
+}
+
+// check returns an error for a thumbnail or cover reference that a media type
+// has no field for, as the file would be uploaded without being used.
+func (refs inputMediaRefs) check(mediaType string, thumbnail, cover bool) error {
+	if refs.thumbnail != "" && !thumbnail {
+		return fmt.Errorf("%s media has no thumbnail", mediaType)
+	}
+	if refs.cover != "" && !cover {
+		return fmt.Errorf("%s media has no cover", mediaType)
+	}
+	return nil
+}
+
+// withInputMediaRefs returns a copy of an InputMedia* value, or of the value
+// a pointer to one refers to, with refs applied and Type set if empty. It also
+// returns the media type.
+func withInputMediaRefs(media any, refs inputMediaRefs) (any, string, error) {
+	set := func(field *string, ref string) {
+		if ref != "" {
+			*field = ref
+		}
+	}
+	setType := func(field *string, mediaType string) string {
+		if *field == "" {
+			*field = mediaType
+		}
+		return *field
+	}
+	switch m := media.(type) {
+	case InputMediaAnimation:
+		if err := refs.check("animation", true, false); err != nil {
+			return nil, "", err
+		}
+		set(&m.Media, refs.media)
+		set(&m.Thumbnail, refs.thumbnail)
+		mediaType := setType(&m.Type, "animation")
+		return m, mediaType, nil
+	case InputMediaAudio:
+		if err := refs.check("audio", true, false); err != nil {
+			return nil, "", err
+		}
+		set(&m.Media, refs.media)
+		set(&m.Thumbnail, refs.thumbnail)
+		mediaType := setType(&m.Type, "audio")
+		return m, mediaType, nil
+	case InputMediaDocument:
+		if err := refs.check("document", true, false); err != nil {
+			return nil, "", err
+		}
+		set(&m.Media, refs.media)
+		set(&m.Thumbnail, refs.thumbnail)
+		mediaType := setType(&m.Type, "document")
+		return m, mediaType, nil
+	case InputMediaPhoto:
+		if err := refs.check("photo", false, false); err != nil {
+			return nil, "", err
+		}
+		set(&m.Media, refs.media)
+		mediaType := setType(&m.Type, "photo")
+		return m, mediaType, nil
+	case InputMediaVideo:
+		if err := refs.check("video", true, true); err != nil {
+			return nil, "", err
+		}
+		set(&m.Media, refs.media)
+		set(&m.Thumbnail, refs.thumbnail)
+		set(&m.Cover, refs.cover)
+		mediaType := setType(&m.Type, "video")
+		return m, mediaType, nil
+	case InputMediaVoiceNote:
+		if err := refs.check("voice_note", false, false); err != nil {
+			return nil, "", err
+		}
+		set(&m.Media, refs.media)
+		mediaType := setType(&m.Type, "voice_note")
+		return m, mediaType, nil
+	case *InputMediaAnimation:
+		if m == nil {
+			return nil, "", errors.New("media is nil")
+		}
+		return withInputMediaRefs(*m, refs)
+	case *InputMediaAudio:
+		if m == nil {
+			return nil, "", errors.New("media is nil")
+		}
+		return withInputMediaRefs(*m, refs)
+	case *InputMediaDocument:
+		if m == nil {
+			return nil, "", errors.New("media is nil")
+		}
+		return withInputMediaRefs(*m, refs)
+	case *InputMediaPhoto:
+		if m == nil {
+			return nil, "", errors.New("media is nil")
+		}
+		return withInputMediaRefs(*m, refs)
+	case *InputMediaVideo:
+		if m == nil {
+			return nil, "", errors.New("media is nil")
+		}
+		return withInputMediaRefs(*m, refs)
+	case *InputMediaVoiceNote:
+		if m == nil {
+			return nil, "", errors.New("media is nil")
+		}
+		return withInputMediaRefs(*m, refs)
+	default:
+		return nil, "", fmt.Errorf("unsupported media type %T", media)
+	}
+}
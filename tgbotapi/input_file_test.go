@@ -0,0 +1,217 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMultipartRequest_Files(t *testing.T) {
+	r := newMultipartRequest(nil)
+	if err := r.addFile("photo", NewInputFileID("file-id")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.addFile("thumbnail", InputFile{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.addFile("video", NewInputFileBytes("v.mp4", []byte("video"))); err != nil {
+		t.Fatal(err)
+	}
+	ref, err := r.attach(NewInputFilePath("cover.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.params["photo"] != "file-id" || len(r.params) != 1 {
+		t.Errorf("params = %v", r.params)
+	}
+	if ref != "attach://file1" || len(r.parts) != 2 || r.parts[0].field != "video" || r.parts[1].path != "cover.jpg" {
+		t.Errorf("ref = %q, parts = %+v", ref, r.parts)
+	}
+	if _, err = inputFileFrom(42); err != ErrBadFileType {
+		t.Errorf("inputFileFrom(42) error = %v, want ErrBadFileType", err)
+	}
+	if err = r.addFile("document", NewInputFileReader("doc.txt", nil)); err == nil {
+		t.Error("addFile() accepted a nil reader")
+	}
+}
+
+func TestSend_NilReader(t *testing.T) {
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			t.Fatalf("unexpected request to %s", r.URL.Path)
+			return nil, nil
+		}),
+	})
+	if _, err := bot.Send(NewDocumentUpload(1, FileReader{Name: "doc.txt", Size: -1})); err == nil {
+		t.Error("Send() accepted a nil reader")
+	}
+	config := NewDocumentUpload(1, "doc.txt")
+	config.Thumbnail = NewInputFileReader("thumb.jpg", nil)
+	if _, err := bot.Send(config); err == nil {
+		t.Error("Send() accepted a nil thumbnail reader")
+	}
+}
+
+// multipartBot returns a bot whose transport passes every parsed multipart
+// request to check and answers with a message.
+func multipartBot(t *testing.T, check func(r *http.Request)) *BotAPI {
+	t.Helper()
+	return NewBotAPIWithClient("123456:TOKEN", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatal(err)
+			}
+			check(r)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"ok":true,"result":{"message_id":7}}`)),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	})
+}
+
+func formFile(t *testing.T, r *http.Request, field string) string {
+	t.Helper()
+	file, _, err := r.FormFile(field)
+	if err != nil {
+		t.Fatalf("%s: %v", field, err)
+	}
+	data, _ := io.ReadAll(file)
+	return string(data)
+}
+
+func TestSend_DocumentStreamsReaderOfUnknownSize(t *testing.T) {
+	bot := multipartBot(t, func(r *http.Request) {
+		if got := formFile(t, r, "document"); got != "document-body" {
+			t.Errorf("document = %q", got)
+		}
+		if got := formFile(t, r, "thumbnail"); got != "thumb" {
+			t.Errorf("thumbnail = %q", got)
+		}
+	})
+	config := NewDocumentUpload(1, FileReader{Name: "doc.txt", Reader: io.MultiReader(strings.NewReader("document-"), strings.NewReader("body")), Size: -1})
+	config.Thumbnail = NewInputFileBytes("thumb.jpg", []byte("thumb"))
+	message, err := bot.Send(config)
+	if err != nil || message.MessageID != 7 {
+		t.Fatalf("message = %+v, err = %v", message, err)
+	}
+}
+
+func TestSend_PhotoUpload(t *testing.T) {
+	bot := multipartBot(t, func(r *http.Request) {
+		if got := formFile(t, r, "photo"); got != "jpeg" {
+			t.Errorf("photo = %q", got)
+		}
+		if r.FormValue("caption") != "Test" {
+			t.Errorf("caption = %q", r.FormValue("caption"))
+		}
+	})
+	config := NewPhotoUpload(1, FileBytes{Name: "image.jpg", Bytes: []byte("jpeg")})
+	config.Caption = "Test"
+	if _, err := bot.Send(config); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.Values(); err == nil {
+		t.Error("Values() must fail for a photo to upload")
+	}
+	if _, err := bot.Send(NewPhotoUpload(1, 42)); err != ErrBadFileType {
+		t.Errorf("bad file error = %v, want ErrBadFileType", err)
+	}
+}
+
+func TestSend_ExistingVideoWithUploadedCover(t *testing.T) {
+	bot := multipartBot(t, func(r *http.Request) {
+		if r.FormValue("video") != "abc" || r.FormValue("caption") != "Test" || r.FormValue("thumbnail") != "thumb-id" {
+			t.Errorf("form = %v", r.MultipartForm.Value)
+		}
+		if r.FormValue("cover") != "attach://file0" {
+			t.Fatalf("cover = %q", r.FormValue("cover"))
+		}
+		if got := formFile(t, r, "file0"); got != "cover" {
+			t.Errorf("file0 = %q", got)
+		}
+	})
+	config := NewVideoShare(1, "abc")
+	config.Caption = "Test"
+	config.Thumbnail = NewInputFileID("thumb-id")
+	config.Cover = NewInputFileBytes("cover.jpg", []byte("cover"))
+	if _, err := bot.Send(config); err != nil {
+		t.Fatal(err)
+	}
+
+	config.Cover = NewInputFileURL("https://example.com/cover.jpg")
+	values, err := config.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Get("thumbnail") != "thumb-id" || values.Get("cover") != "https://example.com/cover.jpg" {
+		t.Errorf("values = %v", values)
+	}
+}
+
+func TestSend_RichMessageMediaUpload(t *testing.T) {
+	bot := multipartBot(t, func(r *http.Request) {
+		var message struct {
+			Media []struct {
+				ID    string         `json:"id"`
+				Media map[string]any `json:"media"`
+			} `json:"media"`
+		}
+		if err := json.Unmarshal([]byte(r.FormValue("rich_message")), &message); err != nil {
+			t.Fatal(err)
+		}
+		if len(message.Media) != 1 || message.Media[0].Media["media"] != "attach://file0" || message.Media[0].Media["type"] != "photo" {
+			t.Errorf("media = %+v", message.Media)
+		}
+		if got := formFile(t, r, "file0"); got != "png" {
+			t.Errorf("file0 = %q", got)
+		}
+	})
+	config := RichMessageConfig{
+		BaseChat: BaseChat{ChatID: 1},
+		RichMessage: InputRichMessage{
+			HTML: `<img src="tg://photo?id=pic">`,
+			Media: []InputRichMessageMedia{
+				{ID: "pic", Media: &InputMediaPhoto{}, File: NewInputFileBytes("pic.png", []byte("png"))},
+			},
+		},
+	}
+	if _, err := bot.Send(config); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEditEphemeralMessageMedia_Upload(t *testing.T) {
+	bot := multipartBot(t, func(r *http.Request) {
+		if got := formFile(t, r, "file0"); got != "video" {
+			t.Errorf("file0 = %q", got)
+		}
+		if got := r.FormValue("media"); got != `{"type":"video","media":"attach://file0","cover":"cover-id"}`+"\n" {
+			t.Errorf("media = %s", got)
+		}
+		if r.FormValue("receiver_user_id") != "7" || r.FormValue("ephemeral_message_id") != "3" {
+			t.Errorf("form = %v", r.MultipartForm.Value)
+		}
+	})
+	config := NewEditEphemeralMessageMedia(-100, 7, 3, &InputMediaVideo{})
+	config.File = NewInputFileBytes("v.mp4", []byte("video"))
+	config.Cover = NewInputFileID("cover-id")
+	if _, err := bot.EditEphemeralMessageMedia(config); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.Values(); err == nil {
+		t.Error("Values() must fail for media to upload")
+	}
+
+	config = NewEditEphemeralMessageMedia(-100, 7, 3, InputMediaPhoto{Type: "photo", Media: "photo-id"})
+	if values, err := config.Values(); err != nil || values.Get("media") != `{"type":"photo","media":"photo-id"}`+"\n" {
+		t.Errorf("values = %v, err = %v", values, err)
+	}
+	config.Thumbnail = NewInputFileID("thumb-id")
+	if _, err := config.Values(); err == nil {
+		t.Error("Values() must fail for a photo thumbnail")
+	}
+}
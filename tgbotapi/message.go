@@ -3,6 +3,7 @@ package tgbotapi
 //go:generate ffjson $GOFILE
 
 import (
+	"encoding/json"
 	"strconv"
 	"strings"
 	"time"
@@ -65,7 +66,7 @@ type Message struct {
 	Chat *Chat `json:"chat,omitempty"`
 
 	// Optional. Information about the original message for forwarded messages
-	ForwardOrigin *MessageOrigin `json:"forward_origin,omitempty"`
+	ForwardOrigin MessageOrigin `json:"forward_origin,omitempty"`
 
 	// Optional. True, if the message is sent to a forum topic or a private chat with the bot
 	IsTopicMessage bool `json:"is_topic_message,omitempty"`
@@ -353,6 +354,24 @@ type Message struct {
 	ForwardDate int `json:"forward_date,omitempty"`
 }
 
+// UnmarshalJSON decodes ForwardOrigin into its MessageOrigin variant.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type alias Message
+	aux := struct {
+		*alias
+		ForwardOrigin json.RawMessage `json:"forward_origin,omitempty"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	origin, err := unmarshalMessageOrigin(aux.ForwardOrigin)
+	if err != nil {
+		return err
+	}
+	m.ForwardOrigin = origin
+	return nil
+}
+
 func (m *Message) GetMessageID() string {
 	return strconv.Itoa(m.MessageID)
 }
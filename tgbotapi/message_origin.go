@@ -1,10 +1,17 @@
 package tgbotapi
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 type MessageOriginType string
 
 const (
 	MessageOriginTypeUser       MessageOriginType = "user"
 	MessageOriginTypeHiddenUser MessageOriginType = "hidden_user"
+	MessageOriginTypeChat       MessageOriginType = "chat"
+	MessageOriginTypeChannel    MessageOriginType = "channel"
 )
 
 type messageOrigin struct {
@@ -12,12 +19,56 @@ type messageOrigin struct {
 	Type MessageOriginType `json:"type"`
 	Date int               `json:"date"`
 }
+
+// MessageOrigin describes the origin of a message. It is one of
+// *MessageOriginUser, *MessageOriginHiddenUser, *MessageOriginChat or
+// *MessageOriginChannel when decoded from JSON:
+//
+//	switch origin := message.ForwardOrigin.(type) {
+//	case *tgbotapi.MessageOriginUser:
+//		log.Println("forwarded from", origin.SenderUser.UserName)
+//	case *tgbotapi.MessageOriginChannel:
+//		log.Println("forwarded from", origin.Chat.Title)
+//	}
+//
+// https://core.telegram.org/bots/api#messageorigin
 type MessageOrigin interface {
 	MessageOriginType() MessageOriginType
 }
 
+// unmarshalMessageOrigin decodes a MessageOrigin variant based on its "type".
+// Origins of a type unknown to this package are decoded as nil.
+func unmarshalMessageOrigin(data json.RawMessage) (MessageOrigin, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var head messageOrigin
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal MessageOrigin type: %w", err)
+	}
+	var origin MessageOrigin
+	switch head.Type {
+	case MessageOriginTypeUser:
+		origin = &MessageOriginUser{}
+	case MessageOriginTypeHiddenUser:
+		origin = &MessageOriginHiddenUser{}
+	case MessageOriginTypeChat:
+		origin = &MessageOriginChat{}
+	case MessageOriginTypeChannel:
+		origin = &MessageOriginChannel{}
+	default:
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, origin); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal MessageOrigin of type %q: %w", head.Type, err)
+	}
+	return origin, nil
+}
+
 var _ MessageOrigin = (*MessageOriginUser)(nil)
 
+// MessageOriginUser is the origin of a message originally sent by a known user.
+// https://core.telegram.org/bots/api#messageoriginuser
 type MessageOriginUser struct {
 	messageOrigin
 	// User that sent the message originally
@@ -28,8 +79,17 @@ func (MessageOriginUser) MessageOriginType() MessageOriginType {
 	return MessageOriginTypeUser
 }
 
+// MarshalJSON always encodes the "user" type.
+func (o MessageOriginUser) MarshalJSON() ([]byte, error) {
+	type alias MessageOriginUser
+	o.Type = MessageOriginTypeUser
+	return json.Marshal(alias(o))
+}
+
 var _ MessageOrigin = (*MessageOriginHiddenUser)(nil)
 
+// MessageOriginHiddenUser is the origin of a message originally sent by an unknown user.
+// https://core.telegram.org/bots/api#messageoriginhiddenuser
 type MessageOriginHiddenUser struct {
 	messageOrigin
 	// Name of the user that sent the message originally
@@ -40,6 +100,17 @@ func (MessageOriginHiddenUser) MessageOriginType() MessageOriginType {
 	return MessageOriginTypeHiddenUser
 }
 
+// MarshalJSON always encodes the "hidden_user" type.
+func (o MessageOriginHiddenUser) MarshalJSON() ([]byte, error) {
+	type alias MessageOriginHiddenUser
+	o.Type = MessageOriginTypeHiddenUser
+	return json.Marshal(alias(o))
+}
+
+var _ MessageOrigin = (*MessageOriginChat)(nil)
+
+// MessageOriginChat is the origin of a message originally sent on behalf of a chat to a group chat.
+// https://core.telegram.org/bots/api#messageoriginchat
 type MessageOriginChat struct {
 	messageOrigin
 	// Chat that sent the message originally
@@ -48,6 +119,21 @@ type MessageOriginChat struct {
 	AuthorSignature string `json:"author_signature,omitempty"`
 }
 
+func (MessageOriginChat) MessageOriginType() MessageOriginType {
+	return MessageOriginTypeChat
+}
+
+// MarshalJSON always encodes the "chat" type.
+func (o MessageOriginChat) MarshalJSON() ([]byte, error) {
+	type alias MessageOriginChat
+	o.Type = MessageOriginTypeChat
+	return json.Marshal(alias(o))
+}
+
+var _ MessageOrigin = (*MessageOriginChannel)(nil)
+
+// MessageOriginChannel is the origin of a message originally sent to a channel chat.
+// https://core.telegram.org/bots/api#messageoriginchannel
 type MessageOriginChannel struct {
 	messageOrigin
 
@@ -60,3 +146,14 @@ type MessageOriginChannel struct {
 	// Optional. For messages originally sent by an anonymous chat administrator, original message author signature
 	AuthorSignature string `json:"author_signature,omitempty"`
 }
+
+func (MessageOriginChannel) MessageOriginType() MessageOriginType {
+	return MessageOriginTypeChannel
+}
+
+// MarshalJSON always encodes the "channel" type.
+func (o MessageOriginChannel) MarshalJSON() ([]byte, error) {
+	type alias MessageOriginChannel
+	o.Type = MessageOriginTypeChannel
+	return json.Marshal(alias(o))
+}
@@ -0,0 +1,65 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMessage_UnmarshalForwardOrigin(t *testing.T) {
+	tests := []struct {
+		origin string
+		want   MessageOrigin
+	}{
+		{`{"type":"user","date":1,"sender_user":{"id":7,"first_name":"Ann"}}`,
+			&MessageOriginUser{messageOrigin{MessageOriginTypeUser, 1}, &User{ID: 7, FirstName: "Ann"}}},
+		{`{"type":"hidden_user","date":2,"sender_user_name":"Bob"}`,
+			&MessageOriginHiddenUser{messageOrigin{MessageOriginTypeHiddenUser, 2}, "Bob"}},
+		{`{"type":"chat","date":3,"sender_chat":{"id":-1,"type":"group"},"author_signature":"admin"}`,
+			&MessageOriginChat{messageOrigin{MessageOriginTypeChat, 3}, Chat{ID: -1, Type: "group"}, "admin"}},
+		{`{"type":"channel","date":4,"chat":{"id":-100,"type":"channel"},"message_id":9}`,
+			&MessageOriginChannel{messageOrigin{MessageOriginTypeChannel, 4}, Chat{ID: -100, Type: "channel"}, 9, ""}},
+		{`{"type":"unknown","date":5}`, nil},
+	}
+	for _, tt := range tests {
+		var message Message
+		if err := json.Unmarshal([]byte(`{"message_id":1,"text":"hi","forward_origin":`+tt.origin+`}`), &message); err != nil {
+			t.Fatalf("%s: %v", tt.origin, err)
+		}
+		if message.Text != "hi" {
+			t.Fatalf("Text = %q, other fields must still be decoded", message.Text)
+		}
+		if !reflect.DeepEqual(message.ForwardOrigin, tt.want) {
+			t.Fatalf("ForwardOrigin = %#v, want %#v", message.ForwardOrigin, tt.want)
+		}
+	}
+}
+
+func TestExternalReplyInfo_RoundTrip(t *testing.T) {
+	message := Message{
+		MessageID: 1,
+		ExternalReply: &ExternalReplyInfo{
+			Origin:    MessageOriginChannel{Chat: Chat{ID: -100, Type: "channel"}, MessageID: 9},
+			MessageID: 9,
+		},
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Message
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	origin, ok := decoded.ExternalReply.Origin.(*MessageOriginChannel)
+	if !ok {
+		t.Fatalf("Origin = %#v, want *MessageOriginChannel", decoded.ExternalReply.Origin)
+	}
+	if origin.Type != MessageOriginTypeChannel || origin.MessageID != 9 || origin.Chat.ID != -100 {
+		t.Fatalf("Origin = %+v", origin)
+	}
+	if decoded.ForwardOrigin != nil {
+		t.Fatalf("ForwardOrigin = %#v, want nil", decoded.ForwardOrigin)
+	}
+}
@@ -1,5 +1,10 @@
 package tgbotapi
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 type Gift struct {
 	ID               string  `json:"id"`                           // Unique identifier of the gift
 	Sticker          Sticker `json:"sticker"`                      // The sticker that represents the gift
@@ -140,6 +145,7 @@ var _ OwnedGift = (*OwnedGiftRegular)(nil)
 // https://core.telegram.org/bots/api#ownedgiftregular
 type OwnedGiftRegular struct {
 	ownedGift
+	Gift                    Gift            `json:"gift"`                                 // Information about the regular gift
 	Text                    string          `json:"text,omitempty"`                       // Optional. Text of the message that was added to the gift
 	Entities                []MessageEntity `json:"entities,omitempty"`                   // Optional. Special entities that appear in the text
 	IsPrivate               bool            `json:"is_private,omitempty"`                 // Optional. True, if the sender and gift text are shown only to the gift receiver; otherwise, everyone will be able to see them
@@ -159,6 +165,10 @@ var _ OwnedGift = (*OwnedGiftUnique)(nil)
 // https://core.telegram.org/bots/api#ownedgiftunique
 type OwnedGiftUnique struct {
 	ownedGift
+	Gift              UniqueGift `json:"gift"`                          // Information about the unique gift
+	CanBeTransferred  bool       `json:"can_be_transferred,omitempty"`  // Optional. True, if the gift can be transferred to another owner; for gifts received on behalf of business accounts only
+	TransferStarCount int        `json:"transfer_star_count,omitempty"` // Optional. Number of Telegram Stars that must be paid to transfer the gift; omitted if the bot cannot transfer the gift
+	NextTransferDate  int        `json:"next_transfer_date,omitempty"`  // Optional. Point in time (Unix timestamp) when the gift can be transferred. If it is in the past, then the gift can be transferred now
 }
 
 func (*OwnedGiftUnique) GetType() OwnedGiftType {
@@ -170,3 +180,47 @@ type OwnedGifts struct {
 	Gifts      []OwnedGift `json:"gifts"`                 // The list of gifts
 	NextOffset string      `json:"next_offset,omitempty"` // Optional. Offset for the next request. If empty, then there are no more results
 }
+
+// UnmarshalJSON decodes Gifts into their OwnedGift variants by type. Gifts of
+// unknown types are skipped.
+func (v *OwnedGifts) UnmarshalJSON(data []byte) error {
+	type alias OwnedGifts
+	aux := struct {
+		*alias
+		Gifts []json.RawMessage `json:"gifts"`
+	}{alias: (*alias)(v)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	v.Gifts = make([]OwnedGift, 0, len(aux.Gifts))
+	for i, data := range aux.Gifts {
+		gift, err := unmarshalOwnedGift(data)
+		if err != nil {
+			return fmt.Errorf("gift %d: %w", i, err)
+		}
+		if gift != nil {
+			v.Gifts = append(v.Gifts, gift)
+		}
+	}
+	return nil
+}
+
+func unmarshalOwnedGift(data json.RawMessage) (OwnedGift, error) {
+	var head ownedGift
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OwnedGift type: %w", err)
+	}
+	var gift OwnedGift
+	switch head.Type {
+	case OwnedGiftTypeRegular:
+		gift = &OwnedGiftRegular{}
+	case OwnedGiftTypeUnique:
+		gift = &OwnedGiftUnique{}
+	default:
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, gift); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OwnedGift of type %q: %w", head.Type, err)
+	}
+	return gift, nil
+}
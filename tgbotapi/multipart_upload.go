@@ -0,0 +1,134 @@
+package tgbotapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/strongo/logus"
+)
+
+// uploadPart is a file sent as a part of a multipart/form-data request.
+// Either path or reader is set.
+type uploadPart struct {
+	field  string
+	name   string
+	path   string
+	reader io.Reader
+}
+
+// sendMultipartRequest sends r as a multipart/form-data request if it has files
+// to upload, and as a regular request otherwise.
+func (bot *BotAPI) sendMultipartRequest(ctx context.Context, endpoint string, r *multipartRequest) (APIResponse, error) {
+	if len(r.parts) == 0 {
+		return bot.MakeRequestContext(ctx, endpoint, r.values())
+	}
+	return bot.uploadParts(ctx, endpoint, r.params, r.parts)
+}
+
+// uploadParts sends params and any number of files in one multipart/form-data
+// request. The body is streamed, so files are never read into memory as a whole.
+func (bot *BotAPI) uploadParts(ctx context.Context, endpoint string, params map[string]string, parts []uploadPart) (apiResp APIResponse, err error) {
+	if err = bot.waitRateLimit(ctx, endpoint, func(name string) string { return params[name] }); err != nil {
+		return
+	}
+
+	body, writer := io.Pipe()
+	mw := multipart.NewWriter(writer)
+	go func() {
+		// The transport closes the body when the request fails, which
+		// unblocks and stops the writer.
+		_ = writer.CloseWithError(writeMultipart(mw, params, parts))
+	}()
+
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, bot.methodURL(endpoint), body); err != nil {
+		_ = body.Close()
+		return
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	return bot.doUploadRequest(endpoint, req)
+}
+
+func writeMultipart(mw *multipart.Writer, params map[string]string, parts []uploadPart) error {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := mw.WriteField(key, params[key]); err != nil {
+			return err
+		}
+	}
+	for _, part := range parts {
+		if err := writeMultipartFile(mw, part); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+func writeMultipartFile(mw *multipart.Writer, part uploadPart) error {
+	reader, name := part.reader, part.name
+	if part.path != "" {
+		file, err := os.Open(part.path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		reader = file
+		if name == "" {
+			name = filepath.Base(part.path)
+		}
+	}
+	w, err := mw.CreateFormFile(part.field, name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+// doUploadRequest executes an upload request and decodes the API response.
+func (bot *BotAPI) doUploadRequest(endpoint string, req *http.Request) (apiResp APIResponse, err error) {
+	var res *http.Response
+	if res, err = bot.Client.Do(req); err != nil {
+		return apiResp, telegramRequestError{method: endpoint, err: err}
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	var body []byte
+	if body, err = io.ReadAll(res.Body); err != nil {
+		return apiResp, telegramResponseIOError{method: endpoint, err: err}
+	}
+
+	if bot.c != nil {
+		logus.Debugf(
+			bot.c,
+			"Telegram API upload response: method=%q, status=%d, body_bytes=%d",
+			endpoint,
+			res.StatusCode,
+			len(body),
+		)
+	}
+
+	if err = json.Unmarshal(body, &apiResp); err != nil {
+		return
+	}
+
+	if !apiResp.Ok {
+		return apiResp, telegramProviderError{method: endpoint, response: apiResp}
+	}
+	return
+}
@@ -42,3 +42,49 @@ type PaidMediaPurchased struct {
 	From             User   `json:"from"`
 	PaidMediaPayload string `json:"paid_media_payload"`
 }
+
+// InputPaidMediaPhoto describes a paid photo to send.
+// https://core.telegram.org/bots/api#inputpaidmediaphoto
+type InputPaidMediaPhoto struct {
+	// Type of the media, must be "photo"
+	Type string `json:"type"`
+
+	// File to send. Pass a file_id to send a file that exists on the Telegram servers, pass an HTTP URL
+	// for Telegram to get a file from the Internet, or pass "attach://<file_attach_name>" to upload a new
+	// one using multipart/form-data under <file_attach_name> name.
+	Media string `json:"media"`
+}
+
+// InputPaidMediaVideo describes a paid video to send.
+// https://core.telegram.org/bots/api#inputpaidmediavideo
+type InputPaidMediaVideo struct {
+	// Type of the media, must be "video"
+	Type string `json:"type"`
+
+	// File to send. Pass a file_id to send a file that exists on the Telegram servers, pass an HTTP URL
+	// for Telegram to get a file from the Internet, or pass "attach://<file_attach_name>" to upload a new
+	// one using multipart/form-data under <file_attach_name> name.
+	Media string `json:"media"`
+
+	// Optional. Thumbnail of the file sent; can be ignored if thumbnail generation for the file is
+	// supported server-side.
+	Thumbnail string `json:"thumbnail,omitempty"`
+
+	// Optional. Cover for the video in the message.
+	Cover string `json:"cover,omitempty"`
+
+	// Optional. Start timestamp for the video in the message.
+	StartTimestamp int `json:"start_timestamp,omitempty"`
+
+	// Optional. Video width.
+	Width int `json:"width,omitempty"`
+
+	// Optional. Video height.
+	Height int `json:"height,omitempty"`
+
+	// Optional. Video duration in seconds.
+	Duration int `json:"duration,omitempty"`
+
+	// Optional. Pass True if the uploaded video is suitable for streaming.
+	SupportsStreaming bool `json:"supports_streaming,omitempty"`
+}
@@ -0,0 +1,101 @@
+package tgbotapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Telegram provider errors. They can be matched with errors.Is against any
+// error returned by BotAPI for an unsuccessful Telegram response, e.g.:
+//
+//	if errors.Is(err, tgbotapi.ErrBotBlocked) {
+//		unsubscribe(chatID)
+//	}
+var (
+	// ErrBotBlocked happens when the user has blocked the bot.
+	ErrBotBlocked = errors.New("bot was blocked by the user")
+
+	// ErrBotKicked happens when the bot was removed from the group or channel.
+	ErrBotKicked = errors.New("bot was kicked from the chat")
+
+	// ErrUserDeactivated happens when the target user account was deleted.
+	ErrUserDeactivated = errors.New("user is deactivated")
+
+	// ErrChatNotFound happens when chat_id does not refer to a chat known to the bot.
+	ErrChatNotFound = errors.New("chat not found")
+
+	// ErrMessageNotModified happens when an edit would leave the message unchanged.
+	ErrMessageNotModified = errors.New("message is not modified")
+
+	// ErrMessageToEditNotFound happens when the message to edit no longer exists.
+	ErrMessageToEditNotFound = errors.New("message to edit not found")
+
+	// ErrQueryTooOld happens when a callback or inline query is answered too late
+	// or its ID is invalid.
+	ErrQueryTooOld = errors.New("query is too old")
+
+	// ErrTooManyRequests happens when flood control is exceeded, see
+	// TelegramProviderErrorDetails.RetryAfter.
+	ErrTooManyRequests = errors.New("too many requests")
+
+	// ErrBadFileID happens when a file_id or file URL is wrong or expired.
+	ErrBadFileID = errors.New("wrong file identifier")
+
+	// ErrChatMigrated happens when a group was upgraded to a supergroup, see
+	// TelegramProviderErrorDetails.MigrateToChatID.
+	ErrChatMigrated = errors.New("group chat was upgraded to a supergroup")
+)
+
+// providerErrorClasses maps Telegram error_code and description fragments to
+// sentinel errors. Descriptions are matched case-insensitively; an empty
+// fragment matches any description with that error_code.
+var providerErrorClasses = []struct {
+	errorCode   int
+	description string
+	err         error
+}{
+	{http.StatusForbidden, "bot was blocked by the user", ErrBotBlocked},
+	{http.StatusForbidden, "bot was kicked from", ErrBotKicked},
+	{http.StatusForbidden, "user is deactivated", ErrUserDeactivated},
+	{http.StatusBadRequest, "chat not found", ErrChatNotFound},
+	{http.StatusBadRequest, "message is not modified", ErrMessageNotModified},
+	{http.StatusBadRequest, "message to edit not found", ErrMessageToEditNotFound},
+	{http.StatusBadRequest, "query is too old", ErrQueryTooOld},
+	{http.StatusBadRequest, "query id is invalid", ErrQueryTooOld},
+	{http.StatusBadRequest, "wrong file identifier", ErrBadFileID},
+	{http.StatusBadRequest, "wrong remote file identifier", ErrBadFileID},
+	{http.StatusBadRequest, "invalid file_id", ErrBadFileID},
+	{http.StatusBadRequest, "group chat was upgraded to a supergroup", ErrChatMigrated},
+	{http.StatusTooManyRequests, "", ErrTooManyRequests},
+}
+
+// classifyAPIResponse returns the sentinel error describing an unsuccessful
+// response, or nil if it is not one of the known cases.
+func classifyAPIResponse(r APIResponse) error {
+	if r.Ok {
+		return nil
+	}
+	if p := r.Parameters; p != nil {
+		if p.RetryAfter > 0 {
+			return ErrTooManyRequests
+		}
+		if p.MigrateToChatID != 0 {
+			return ErrChatMigrated
+		}
+	}
+	description := strings.ToLower(r.Description)
+	for _, class := range providerErrorClasses {
+		if class.errorCode == r.ErrorCode && strings.Contains(description, class.description) {
+			return class.err
+		}
+	}
+	return nil
+}
+
+// Is reports whether the response is classified as target, so that
+// errors.Is(err, ErrBotBlocked) works for provider errors.
+func (r APIResponse) Is(target error) bool {
+	kind := classifyAPIResponse(r)
+	return kind != nil && kind == target
+}
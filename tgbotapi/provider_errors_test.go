@@ -0,0 +1,81 @@
+package tgbotapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestMakeRequest_ClassifiesProviderErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{http.StatusForbidden, `{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`, ErrBotBlocked},
+		{http.StatusForbidden, `{"ok":false,"error_code":403,"description":"Forbidden: bot was kicked from the supergroup chat"}`, ErrBotKicked},
+		{http.StatusForbidden, `{"ok":false,"error_code":403,"description":"Forbidden: user is deactivated"}`, ErrUserDeactivated},
+		{http.StatusBadRequest, `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`, ErrChatNotFound},
+		{http.StatusBadRequest, `{"ok":false,"error_code":400,"description":"Bad Request: message is not modified: specified new message content and reply markup are exactly the same"}`, ErrMessageNotModified},
+		{http.StatusBadRequest, `{"ok":false,"error_code":400,"description":"Bad Request: message to edit not found"}`, ErrMessageToEditNotFound},
+		{http.StatusBadRequest, `{"ok":false,"error_code":400,"description":"Bad Request: query is too old and response timeout expired or query ID is invalid"}`, ErrQueryTooOld},
+		{http.StatusBadRequest, `{"ok":false,"error_code":400,"description":"Bad Request: wrong file identifier/HTTP URL specified"}`, ErrBadFileID},
+		{http.StatusBadRequest, `{"ok":false,"error_code":400,"description":"Bad Request: group chat was upgraded to a supergroup chat","parameters":{"migrate_to_chat_id":-100123}}`, ErrChatMigrated},
+		{http.StatusTooManyRequests, `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 5","parameters":{"retry_after":5}}`, ErrTooManyRequests},
+		{http.StatusBadRequest, `{"ok":false,"error_code":400,"description":"Bad Request: something new"}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.want), func(t *testing.T) {
+			bot := testBotWithResponse("123456:TOKEN", tt.status, tt.body)
+
+			_, err := bot.MakeRequest("sendMessage", nil)
+			if err == nil {
+				t.Fatal("MakeRequest() error = nil, want provider error")
+			}
+			details, ok := TelegramProviderErrorDetailsFrom(fmt.Errorf("wrapped: %w", err))
+			if !ok {
+				t.Fatalf("TelegramProviderErrorDetailsFrom() = false for %v", err)
+			}
+			if details.Kind != tt.want {
+				t.Fatalf("details.Kind = %v, want %v", details.Kind, tt.want)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("errors.Is(%v, %v) = false", err, tt.want)
+			}
+			if tt.want != ErrChatNotFound && errors.Is(err, ErrChatNotFound) {
+				t.Fatalf("errors.Is(%v, ErrChatNotFound) = true", err)
+			}
+		})
+	}
+}
+
+func TestMakeRequest_ForbiddenKeepsErrAPIForbidden(t *testing.T) {
+	const description = "Forbidden: bot was blocked by the user"
+	bot := testBotWithResponse("123456:TOKEN", http.StatusForbidden, `{"ok":false,"error_code":403,"description":"`+description+`"}`)
+
+	_, err := bot.MakeRequest("sendMessage", nil)
+	var forbidden *ErrAPIForbidden
+	if !errors.As(err, &forbidden) {
+		t.Fatalf("MakeRequest() error = %T, want *ErrAPIForbidden", err)
+	}
+	if err.Error() != "forbidden" {
+		t.Fatalf("MakeRequest() error = %q, want %q", err, "forbidden")
+	}
+	if !errors.Is(err, ErrBotBlocked) {
+		t.Fatal("errors.Is(err, ErrBotBlocked) = false")
+	}
+}
+
+func TestMakeRequest_ForbiddenWithoutJSONBody(t *testing.T) {
+	bot := testBotWithResponse("123456:TOKEN", http.StatusForbidden, "forbidden")
+
+	_, err := bot.MakeRequest("sendMessage", nil)
+	var forbidden *ErrAPIForbidden
+	if !errors.As(err, &forbidden) {
+		t.Fatalf("MakeRequest() error = %T, want *ErrAPIForbidden", err)
+	}
+	if _, ok := TelegramProviderErrorDetailsFrom(err); ok {
+		t.Fatal("TelegramProviderErrorDetailsFrom() = true for undecodable body")
+	}
+}
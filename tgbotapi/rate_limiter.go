@@ -0,0 +1,252 @@
+package tgbotapi
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter throttles outgoing requests before BotAPI sends them.
+//
+// Assign an implementation to BotAPI.RateLimiter to enable it; a nil limiter
+// sends requests immediately.
+type RateLimiter interface {
+	// Wait blocks until req may be sent, or returns ctx.Err() if ctx is done first.
+	Wait(ctx context.Context, req RateLimitRequest) error
+}
+
+// RateLimitRequest describes an outgoing request to a RateLimiter.
+type RateLimitRequest struct {
+	// Method is the Telegram API method, e.g. "sendMessage".
+	Method string
+
+	// ChatID is the raw chat_id parameter: a numeric ID or an @channelusername.
+	// It is empty for methods that do not target a chat.
+	ChatID string
+
+	// PaidBroadcast is true when the request carries allow_paid_broadcast.
+	PaidBroadcast bool
+
+	// Messages is the number of messages the request sends: the media of
+	// sendMediaGroup or the message_ids of copyMessages and forwardMessages.
+	// Zero is treated as one.
+	Messages int
+}
+
+// Rate is a budget of Events per Per interval.
+type Rate struct {
+	Events int
+	Per    time.Duration
+}
+
+func (r Rate) perSecond() float64 {
+	return float64(r.Events) / r.Per.Seconds()
+}
+
+// Clock abstracts time so that rate limiting can be tested without sleeping.
+type Clock interface {
+	Now() time.Time
+	// Sleep waits for d, or returns ctx.Err() if ctx is done first.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Telegram broadcasting limits, see https://core.telegram.org/bots/faq#my-bot-is-hitting-limits-how-do-i-avoid-this
+var (
+	// DefaultGlobalRate is the overall budget for outgoing messages.
+	DefaultGlobalRate = Rate{Events: 30, Per: time.Second}
+
+	// DefaultPaidBroadcastRate is the budget for messages sent with allow_paid_broadcast.
+	DefaultPaidBroadcastRate = Rate{Events: 1000, Per: time.Second}
+
+	// DefaultPrivateChatRate is the budget for a single private chat.
+	DefaultPrivateChatRate = Rate{Events: 1, Per: time.Second}
+
+	// DefaultGroupChatRate is the budget for a single group, supergroup or channel.
+	DefaultGroupChatRate = Rate{Events: 20, Per: time.Minute}
+)
+
+// chatBucketsPruneThreshold is the number of tracked chats above which
+// per-chat buckets that have refilled to capacity are dropped.
+const chatBucketsPruneThreshold = 1024
+
+var _ RateLimiter = (*TelegramRateLimiter)(nil)
+
+// TelegramRateLimiter is a RateLimiter that enforces Telegram's documented
+// broadcasting limits: a global budget shared by all chats, and a per-chat
+// budget that depends on whether chat_id refers to a private chat (positive ID)
+// or a group/channel (negative ID or @username).
+//
+// Requests sent with allow_paid_broadcast use PaidBroadcast instead of Global.
+// Only message-producing methods (send*, forward*, copy*) are limited, each
+// message of an album or a batch taking its own share of the budgets;
+// sendChatAction and everything else pass through.
+type TelegramRateLimiter struct {
+	Global        Rate
+	PaidBroadcast Rate
+	PrivateChat   Rate
+	GroupChat     Rate
+
+	// Clock defaults to the system clock when nil.
+	Clock Clock
+
+	mu     sync.Mutex
+	global tokenBucket
+	paid   tokenBucket
+	chats  map[string]*tokenBucket
+}
+
+// NewTelegramRateLimiter creates a TelegramRateLimiter with Telegram's default limits.
+func NewTelegramRateLimiter() *TelegramRateLimiter {
+	return &TelegramRateLimiter{
+		Global:        DefaultGlobalRate,
+		PaidBroadcast: DefaultPaidBroadcastRate,
+		PrivateChat:   DefaultPrivateChatRate,
+		GroupChat:     DefaultGroupChatRate,
+	}
+}
+
+// Wait implements RateLimiter.
+func (l *TelegramRateLimiter) Wait(ctx context.Context, req RateLimitRequest) error {
+	if !isRateLimitedMethod(req.Method) {
+		return nil
+	}
+	clock := l.clock()
+	n := max(req.Messages, 1)
+
+	l.mu.Lock()
+	now := clock.Now()
+	globalBucket, globalRate := &l.global, l.Global
+	if req.PaidBroadcast {
+		globalBucket, globalRate = &l.paid, l.PaidBroadcast
+	}
+	delay := globalBucket.reserve(now, globalRate, n)
+	var chatBucket *tokenBucket
+	chatRate := l.chatRate(req.ChatID)
+	if req.ChatID != "" {
+		chatBucket = l.chatBucket(now, req.ChatID)
+		if chatDelay := chatBucket.reserve(now, chatRate, n); chatDelay > delay {
+			delay = chatDelay
+		}
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	if err := clock.Sleep(ctx, delay); err != nil {
+		l.mu.Lock()
+		globalBucket.release(globalRate, n)
+		if chatBucket != nil {
+			chatBucket.release(chatRate, n)
+		}
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (l *TelegramRateLimiter) clock() Clock {
+	if l.Clock == nil {
+		return systemClock{}
+	}
+	return l.Clock
+}
+
+func (l *TelegramRateLimiter) chatRate(chatID string) Rate {
+	if chatID != "" && chatID[0] != '-' && chatID[0] != '@' {
+		return l.PrivateChat
+	}
+	return l.GroupChat
+}
+
+// chatBucket must be called with l.mu held.
+func (l *TelegramRateLimiter) chatBucket(now time.Time, chatID string) *tokenBucket {
+	if l.chats == nil {
+		l.chats = make(map[string]*tokenBucket)
+	}
+	if b, ok := l.chats[chatID]; ok {
+		return b
+	}
+	if len(l.chats) >= chatBucketsPruneThreshold {
+		for id, b := range l.chats {
+			if b.isFull(now, l.chatRate(id)) {
+				delete(l.chats, id)
+			}
+		}
+	}
+	b := &tokenBucket{}
+	l.chats[chatID] = b
+	return b
+}
+
+func isRateLimitedMethod(method string) bool {
+	if method == "sendChatAction" {
+		return false
+	}
+	return strings.HasPrefix(method, "send") ||
+		strings.HasPrefix(method, "forward") ||
+		strings.HasPrefix(method, "copy")
+}
+
+// tokenBucket holds up to Rate.Events tokens that refill evenly over Rate.Per.
+// Tokens may go negative: that represents requests already queued for the future.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes n tokens and returns how long the caller must wait for them.
+func (b *tokenBucket) reserve(now time.Time, r Rate, n int) time.Duration {
+	if r.Events <= 0 || r.Per <= 0 {
+		return 0
+	}
+	capacity := float64(r.Events)
+	if b.last.IsZero() {
+		b.tokens = capacity
+	} else if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * r.perSecond()
+		if b.tokens > capacity {
+			b.tokens = capacity
+		}
+	}
+	b.last = now
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / r.perSecond() * float64(time.Second))
+}
+
+// release returns the n tokens taken by reserve for a request that was not sent.
+func (b *tokenBucket) release(r Rate, n int) {
+	if r.Events > 0 && r.Per > 0 {
+		b.tokens += float64(n)
+	}
+}
+
+// isFull reports whether the bucket has refilled to capacity by now, so that
+// dropping it and starting a new one later doesn't grant extra tokens.
+func (b *tokenBucket) isFull(now time.Time, r Rate) bool {
+	if b.last.IsZero() || r.Events <= 0 || r.Per <= 0 {
+		return true
+	}
+	return b.tokens+now.Sub(b.last).Seconds()*r.perSecond() >= float64(r.Events)
+}
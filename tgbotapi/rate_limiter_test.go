@@ -0,0 +1,252 @@
+package tgbotapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock advances only when Sleep is called, so limiter delays are observable
+// without real waiting.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return nil
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) totalSlept() (total time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, d := range c.sleeps {
+		total += d
+	}
+	return total
+}
+
+func newTestRateLimiter() (*TelegramRateLimiter, *fakeClock) {
+	clock := newFakeClock()
+	limiter := NewTelegramRateLimiter()
+	limiter.Clock = clock
+	return limiter, clock
+}
+
+func TestTelegramRateLimiter_PrivateChat(t *testing.T) {
+	limiter, clock := newTestRateLimiter()
+	req := RateLimitRequest{Method: "sendMessage", ChatID: "42"}
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := clock.totalSlept(); got != 2*time.Second {
+		t.Fatalf("slept %v for 3 messages to a private chat, want 2s", got)
+	}
+}
+
+func TestTelegramRateLimiter_GroupChat(t *testing.T) {
+	limiter, clock := newTestRateLimiter()
+	req := RateLimitRequest{Method: "sendMessage", ChatID: "-100123"}
+
+	for i := 0; i < 20; i++ {
+		if err := limiter.Wait(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := clock.totalSlept(); got != 0 {
+		t.Fatalf("slept %v for 20 messages to a group, want burst without waiting", got)
+	}
+	if err := limiter.Wait(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if got := clock.totalSlept(); got != 3*time.Second {
+		t.Fatalf("slept %v for 21st group message, want 3s", got)
+	}
+}
+
+func TestTelegramRateLimiter_WeightsBatches(t *testing.T) {
+	limiter, clock := newTestRateLimiter()
+
+	if err := limiter.Wait(context.Background(), RateLimitRequest{Method: "sendMediaGroup", ChatID: "42", Messages: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if got := clock.totalSlept(); got != 4*time.Second {
+		t.Fatalf("slept %v for an album of 5 to a private chat, want 4s", got)
+	}
+	if err := limiter.Wait(context.Background(), RateLimitRequest{Method: "sendMessage", ChatID: "42"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := clock.totalSlept(); got != 5*time.Second {
+		t.Fatalf("slept %v for a message after the album, want 5s", got)
+	}
+}
+
+func TestTelegramRateLimiter_PrunesOnlyRefilledChats(t *testing.T) {
+	limiter, clock := newTestRateLimiter()
+	now := clock.Now()
+	group := limiter.chatBucket(now, "-100123")
+	for i := 0; i < 25; i++ {
+		group.reserve(now, limiter.GroupChat, 1)
+	}
+	for i := 1; len(limiter.chats) < chatBucketsPruneThreshold; i++ {
+		limiter.chatBucket(now, strconv.Itoa(i)).reserve(now, limiter.PrivateChat, 1)
+	}
+
+	// A minute later the group bucket has refilled 20 of 25 tokens only.
+	clock.Advance(time.Minute)
+	limiter.chatBucket(clock.Now(), "-100456")
+	if len(limiter.chats) != 2 || limiter.chats["-100123"] != group {
+		t.Fatalf("tracked %d chats after pruning, want the group in deficit and the new chat", len(limiter.chats))
+	}
+}
+
+func TestTelegramRateLimiter_Global(t *testing.T) {
+	limiter, clock := newTestRateLimiter()
+
+	for i := 0; i < 31; i++ {
+		req := RateLimitRequest{Method: "sendMessage", ChatID: "1" + strings.Repeat("0", i)} // distinct private chats
+		if err := limiter.Wait(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := clock.totalSlept(), time.Second/30; got < want-time.Millisecond || got > want+time.Millisecond {
+		t.Fatalf("slept %v for 31st message, want about %v", got, want)
+	}
+}
+
+func TestTelegramRateLimiter_PaidBroadcastBypassesGlobal(t *testing.T) {
+	limiter, clock := newTestRateLimiter()
+
+	for i := 0; i < 100; i++ {
+		req := RateLimitRequest{Method: "sendMessage", ChatID: "1" + strings.Repeat("0", i), PaidBroadcast: true}
+		if err := limiter.Wait(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := clock.totalSlept(); got != 0 {
+		t.Fatalf("slept %v for 100 paid broadcast messages, want 0", got)
+	}
+}
+
+func TestTelegramRateLimiter_RefillsOverTime(t *testing.T) {
+	limiter, clock := newTestRateLimiter()
+	req := RateLimitRequest{Method: "sendPhoto", ChatID: "42"}
+
+	_ = limiter.Wait(context.Background(), req)
+	clock.Advance(time.Second)
+	_ = limiter.Wait(context.Background(), req)
+	if got := clock.totalSlept(); got != 0 {
+		t.Fatalf("slept %v after the per-chat budget refilled, want 0", got)
+	}
+}
+
+func TestTelegramRateLimiter_IgnoresNonMessageMethods(t *testing.T) {
+	limiter, clock := newTestRateLimiter()
+
+	for _, method := range []string{"getUpdates", "sendChatAction", "getChat", "sendChatAction"} {
+		if err := limiter.Wait(context.Background(), RateLimitRequest{Method: method, ChatID: "42"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := clock.totalSlept(); got != 0 {
+		t.Fatalf("slept %v, want 0", got)
+	}
+}
+
+func TestTelegramRateLimiter_CancelledReleasesReservation(t *testing.T) {
+	limiter, clock := newTestRateLimiter()
+	req := RateLimitRequest{Method: "sendMessage", ChatID: "42"}
+	_ = limiter.Wait(context.Background(), req)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.Wait(ctx, req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait() error = %v, want context.Canceled", err)
+	}
+
+	clock.Advance(time.Second)
+	if err := limiter.Wait(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if got := clock.totalSlept(); got != 0 {
+		t.Fatalf("slept %v, cancelled reservation was not released", got)
+	}
+}
+
+type recordingRateLimiter struct {
+	requests []RateLimitRequest
+}
+
+func (l *recordingRateLimiter) Wait(_ context.Context, req RateLimitRequest) error {
+	l.requests = append(l.requests, req)
+	return nil
+}
+
+func TestBotAPI_SendUsesRateLimiter(t *testing.T) {
+	limiter := &recordingRateLimiter{}
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			body := `{"ok":true,"result":{"message_id":1}}`
+			if strings.HasSuffix(r.URL.Path, "/copyMessages") {
+				body = `{"ok":true,"result":[{"message_id":1},{"message_id":2},{"message_id":3}]}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	})
+	bot.RateLimiter = limiter
+
+	msg := NewMessage(42, "hi")
+	msg.AllowPaidBroadcast = true
+	if _, err := bot.Send(msg); err != nil {
+		t.Fatal(err)
+	}
+	want := RateLimitRequest{Method: "sendMessage", ChatID: "42", PaidBroadcast: true, Messages: 1}
+	if len(limiter.requests) != 1 || limiter.requests[0] != want {
+		t.Fatalf("RateLimiter got %#v, want [%#v]", limiter.requests, want)
+	}
+
+	if _, err := bot.CopyMessages(CopyMessagesConfig{ChatID: -100, FromChatID: 42, MessageIDs: []int{3, 4, 5}}); err != nil {
+		t.Fatal(err)
+	}
+	want = RateLimitRequest{Method: "copyMessages", ChatID: "-100", Messages: 3}
+	if len(limiter.requests) != 2 || limiter.requests[1] != want {
+		t.Fatalf("RateLimiter got %#v, want %#v", limiter.requests[1:], want)
+	}
+}
@@ -1,5 +1,11 @@
 package tgbotapi
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
 // ReactionType describes a reaction type. Currently, it can be one of:
 // - ReactionTypeEmoji
 // - ReactionTypeCustomEmoji
@@ -43,3 +49,57 @@ type MessageReactionCountUpdated struct {
 	Date      int             `json:"date"`
 	Reactions []ReactionCount `json:"reactions"`
 }
+
+// Reaction types, see ReactionType.Type.
+const (
+	ReactionTypeEmoji       = "emoji"
+	ReactionTypeCustomEmoji = "custom_emoji"
+	ReactionTypePaid        = "paid"
+)
+
+// NewReactionEmoji returns a reaction with a standard emoji, e.g. "👍".
+func NewReactionEmoji(emoji string) ReactionType {
+	return ReactionType{Type: ReactionTypeEmoji, Emoji: emoji}
+}
+
+// NewReactionCustomEmoji returns a reaction with a custom emoji.
+func NewReactionCustomEmoji(customEmojiID string) ReactionType {
+	return ReactionType{Type: ReactionTypeCustomEmoji, CustomEmojiID: customEmojiID}
+}
+
+// NewReactionPaid returns the paid reaction. Bots can't set it, but can
+// find it in reaction updates.
+func NewReactionPaid() ReactionType {
+	return ReactionType{Type: ReactionTypePaid}
+}
+
+// Validate returns an error if the reaction type is unknown or its emoji is missing.
+func (r ReactionType) Validate() error {
+	switch r.Type {
+	case ReactionTypeEmoji:
+		if r.Emoji == "" {
+			return errors.New("emoji reaction requires an emoji")
+		}
+	case ReactionTypeCustomEmoji:
+		if r.CustomEmojiID == "" {
+			return errors.New("custom emoji reaction requires a custom_emoji_id")
+		}
+	case ReactionTypePaid:
+	default:
+		return fmt.Errorf("unknown reaction type %q", r.Type)
+	}
+	return nil
+}
+
+// IsReactionAvailable reports whether reaction is allowed in the chat by
+// AvailableReactions, which is only known for a chat returned by getChat.
+// If AvailableReactions is nil all emoji reactions are allowed, custom emoji
+// only if they are already present on the message, which is not checked.
+// The paid reaction is not listed in AvailableReactions and is reported as
+// available.
+func (c *Chat) IsReactionAvailable(reaction ReactionType) bool {
+	if reaction.Type == ReactionTypePaid || c.AvailableReactions == nil {
+		return true
+	}
+	return slices.Contains(c.AvailableReactions, reaction)
+}
@@ -1,5 +1,7 @@
 package tgbotapi
 
+import "encoding/json"
+
 // TextQuote contains information about the quoted part of a message that is replied to.
 // https://core.telegram.org/bots/api#textquote
 type TextQuote struct {
@@ -46,3 +48,21 @@ type InaccessibleMessage struct {
 	MessageID int  `json:"message_id"`
 	Date      int  `json:"date"` // Always 0
 }
+
+// UnmarshalJSON decodes Origin into its MessageOrigin variant.
+func (r *ExternalReplyInfo) UnmarshalJSON(data []byte) error {
+	type alias ExternalReplyInfo
+	aux := struct {
+		*alias
+		Origin json.RawMessage `json:"origin"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	origin, err := unmarshalMessageOrigin(aux.Origin)
+	if err != nil {
+		return err
+	}
+	r.Origin = origin
+	return nil
+}
@@ -0,0 +1,63 @@
+package tgbotapi
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// RetryPolicy controls how BotAPI reacts to Telegram's ResponseParameters.
+//
+// The zero value disables both behaviours, so existing callers keep receiving
+// 429 and migration errors unchanged. Retries apply to form requests made via
+// MakeRequestContext; uploads are never repeated because their readers may not
+// be replayable.
+type RetryPolicy struct {
+	// MaxRetries is how many times a request is repeated after a flood-control
+	// (429) response carrying retry_after.
+	MaxRetries int
+
+	// MaxRetryAfter caps a single wait. When Telegram asks to wait longer the
+	// error is returned to the caller instead. Zero means no cap.
+	MaxRetryAfter time.Duration
+
+	// FollowChatMigration repeats the request once against migrate_to_chat_id
+	// when the target group has been upgraded to a supergroup.
+	FollowChatMigration bool
+}
+
+// retryAfter returns how long to wait before attempt number retries+1,
+// or false if the policy does not allow another attempt.
+func (p RetryPolicy) retryAfter(retries, retryAfterSeconds int) (time.Duration, bool) {
+	if retries >= p.MaxRetries {
+		return 0, false
+	}
+	wait := time.Duration(retryAfterSeconds) * time.Second
+	if p.MaxRetryAfter > 0 && wait > p.MaxRetryAfter {
+		return 0, false
+	}
+	return wait, true
+}
+
+// sleepContext waits for d or until ctx is done, whichever happens first.
+func (bot *BotAPI) sleepContext(ctx context.Context, d time.Duration) error {
+	if bot.sleep != nil {
+		return bot.sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func cloneValues(values url.Values) url.Values {
+	clone := make(url.Values, len(values))
+	for k, v := range values {
+		clone[k] = append([]string(nil), v...)
+	}
+	return clone
+}
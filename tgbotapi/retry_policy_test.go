@@ -0,0 +1,150 @@
+package tgbotapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scriptedBot returns a bot whose transport replies with bodies in order and
+// records the chat_id of every request.
+func scriptedBot(t *testing.T, bodies ...string) (*BotAPI, *[]string) {
+	t.Helper()
+	var chatIDs []string
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			chatIDs = append(chatIDs, r.PostForm.Get("chat_id"))
+			if len(bodies) == 0 {
+				t.Fatal("unexpected request")
+			}
+			body := bodies[0]
+			bodies = bodies[1:]
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	})
+	return bot, &chatIDs
+}
+
+const (
+	floodResponse     = `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 3","parameters":{"retry_after":3}}`
+	migrateResponse   = `{"ok":false,"error_code":400,"description":"Bad Request: group chat was upgraded to a supergroup chat","parameters":{"migrate_to_chat_id":-1001234}}`
+	okMessageResponse = `{"ok":true,"result":{"message_id":1}}`
+)
+
+func TestTelegramProviderErrorDetailsFrom_ResponseParameters(t *testing.T) {
+	bot, _ := scriptedBot(t, floodResponse)
+
+	_, err := bot.MakeRequest("sendMessage", url.Values{"chat_id": {"1"}})
+	details, ok := TelegramProviderErrorDetailsFrom(err)
+	if !ok {
+		t.Fatalf("TelegramProviderErrorDetailsFrom() = false, want true: %v", err)
+	}
+	if details.RetryAfter != 3*time.Second {
+		t.Fatalf("RetryAfter = %v, want 3s", details.RetryAfter)
+	}
+}
+
+func TestMakeRequestContext_RetryAfter(t *testing.T) {
+	bot, chatIDs := scriptedBot(t, floodResponse, floodResponse, okMessageResponse)
+	bot.RetryPolicy = RetryPolicy{MaxRetries: 2, MaxRetryAfter: 5 * time.Second}
+	var waits []time.Duration
+	bot.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	if _, err := bot.MakeRequest("sendMessage", url.Values{"chat_id": {"1"}}); err != nil {
+		t.Fatalf("MakeRequest() error = %v", err)
+	}
+	if len(*chatIDs) != 3 {
+		t.Fatalf("requests = %d, want 3", len(*chatIDs))
+	}
+	if len(waits) != 2 || waits[0] != 3*time.Second {
+		t.Fatalf("waits = %v, want [3s 3s]", waits)
+	}
+}
+
+func TestMakeRequestContext_RetryAfterExceedsCap(t *testing.T) {
+	bot, chatIDs := scriptedBot(t, floodResponse)
+	bot.RetryPolicy = RetryPolicy{MaxRetries: 5, MaxRetryAfter: time.Second}
+
+	_, err := bot.MakeRequest("sendMessage", url.Values{"chat_id": {"1"}})
+	if err == nil {
+		t.Fatal("MakeRequest() error = nil, want flood-control error")
+	}
+	if len(*chatIDs) != 1 {
+		t.Fatalf("requests = %d, want 1", len(*chatIDs))
+	}
+}
+
+func TestMakeRequestContext_RetryAfterDisabledByDefault(t *testing.T) {
+	bot, chatIDs := scriptedBot(t, floodResponse)
+
+	if _, err := bot.MakeRequest("sendMessage", url.Values{"chat_id": {"1"}}); err == nil {
+		t.Fatal("MakeRequest() error = nil, want flood-control error")
+	}
+	if len(*chatIDs) != 1 {
+		t.Fatalf("requests = %d, want 1", len(*chatIDs))
+	}
+}
+
+func TestMakeRequestContext_RetryAfterCancelled(t *testing.T) {
+	bot, _ := scriptedBot(t, floodResponse)
+	bot.RetryPolicy = RetryPolicy{MaxRetries: 1}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bot.sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	_, err := bot.MakeRequestContext(ctx, "sendMessage", url.Values{"chat_id": {"1"}})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("MakeRequestContext() error = %v, want context.Canceled", err)
+	}
+	if _, ok := TelegramProviderErrorDetailsFrom(err); !ok {
+		t.Fatalf("MakeRequestContext() error does not preserve provider details: %v", err)
+	}
+}
+
+func TestMakeRequestContext_FollowChatMigration(t *testing.T) {
+	bot, chatIDs := scriptedBot(t, migrateResponse, okMessageResponse)
+	bot.RetryPolicy = RetryPolicy{FollowChatMigration: true}
+
+	params := url.Values{"chat_id": {"-1234"}}
+	if _, err := bot.MakeRequest("sendMessage", params); err != nil {
+		t.Fatalf("MakeRequest() error = %v", err)
+	}
+	if got := *chatIDs; len(got) != 2 || got[0] != "-1234" || got[1] != "-1001234" {
+		t.Fatalf("chat_id per request = %v, want [-1234 -1001234]", got)
+	}
+	if params.Get("chat_id") != "-1234" {
+		t.Fatal("MakeRequest() mutated caller params")
+	}
+}
+
+func TestMakeRequestContext_ChatMigrationNotFollowed(t *testing.T) {
+	bot, chatIDs := scriptedBot(t, migrateResponse)
+
+	_, err := bot.MakeRequest("sendMessage", url.Values{"chat_id": {"-1234"}})
+	details, ok := TelegramProviderErrorDetailsFrom(err)
+	if !ok || details.MigrateToChatID != -1001234 {
+		t.Fatalf("TelegramProviderErrorDetailsFrom() = (%#v, %t), want MigrateToChatID", details, ok)
+	}
+	if len(*chatIDs) != 1 {
+		t.Fatalf("requests = %d, want 1", len(*chatIDs))
+	}
+}
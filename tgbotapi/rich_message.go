@@ -65,6 +65,10 @@ type InputRichMessageMedia struct {
 	// type-specific properties (e.g. width/height/duration) is ignored - in particular, any caption set
 	// on the referenced InputMedia* value is ignored.
 	Media any `json:"media"`
+
+	// File, if set, is the file of Media. It may be a new upload, which
+	// RichMessageConfig sends as a multipart request.
+	File InputFile `json:"-"`
 }
 
 var _ InputMessageContent = (*InputRichMessageContent)(nil)
@@ -0,0 +1,557 @@
+package tgbotapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// MaxStarTransactionsLimit is the maximum number of transactions returned by
+// a single getStarTransactions call.
+const MaxStarTransactionsLimit = 100
+
+// TransactionPartnerType is the type of a TransactionPartner.
+type TransactionPartnerType string
+
+const (
+	TransactionPartnerTypeUser             TransactionPartnerType = "user"
+	TransactionPartnerTypeChat             TransactionPartnerType = "chat"
+	TransactionPartnerTypeAffiliateProgram TransactionPartnerType = "affiliate_program"
+	TransactionPartnerTypeFragment         TransactionPartnerType = "fragment"
+	TransactionPartnerTypeTelegramAds      TransactionPartnerType = "telegram_ads"
+	TransactionPartnerTypeTelegramAPI      TransactionPartnerType = "telegram_api"
+	TransactionPartnerTypeOther            TransactionPartnerType = "other"
+)
+
+// Transaction type constants for TransactionPartnerUser.TransactionType.
+const (
+	TransactionTypeInvoicePayment          = "invoice_payment"
+	TransactionTypePaidMediaPayment        = "paid_media_payment"
+	TransactionTypeGiftPurchase            = "gift_purchase"
+	TransactionTypePremiumPurchase         = "premium_purchase"
+	TransactionTypeBusinessAccountTransfer = "business_account_transfer"
+)
+
+// Revenue withdrawal state constants for RevenueWithdrawalState.Type.
+const (
+	RevenueWithdrawalStatePending   = "pending"
+	RevenueWithdrawalStateSucceeded = "succeeded"
+	RevenueWithdrawalStateFailed    = "failed"
+)
+
+// StarAmount describes an amount of Telegram Stars.
+// https://core.telegram.org/bots/api#staramount
+type StarAmount struct {
+	// Integer amount of Telegram Stars, rounded to 0; can be negative
+	Amount int `json:"amount"`
+
+	// Optional. The number of 1/1000000000 shares of Telegram Stars; from -999999999 to 999999999; can be negative if and only if amount is non-positive
+	NanostarAmount int `json:"nanostar_amount,omitempty"`
+}
+
+// RevenueWithdrawalState describes the state of a revenue withdrawal operation.
+// Fields that are not relevant for the Type are left empty.
+// https://core.telegram.org/bots/api#revenuewithdrawalstate
+type RevenueWithdrawalState struct {
+	// Type of the state: "pending", "succeeded" or "failed"
+	Type string `json:"type"`
+
+	// Succeeded only. Date the withdrawal was completed in Unix time
+	Date int `json:"date,omitempty"`
+
+	// Succeeded only. An HTTPS URL that can be used to see transaction details
+	URL string `json:"url,omitempty"`
+}
+
+// AffiliateInfo contains information about the affiliate that received a
+// commission via this transaction.
+// https://core.telegram.org/bots/api#affiliateinfo
+type AffiliateInfo struct {
+	// Optional. The bot or the user that received an affiliate commission if it was received by a bot or a user
+	AffiliateUser *User `json:"affiliate_user,omitempty"`
+
+	// Optional. The chat that received an affiliate commission if it was received by a chat
+	AffiliateChat *Chat `json:"affiliate_chat,omitempty"`
+
+	// The number of Telegram Stars received by the affiliate for each 1000 Telegram Stars received by the bot from referred users
+	CommissionPerMille int `json:"commission_per_mille"`
+
+	// Integer amount of Telegram Stars received by the affiliate from the transaction, rounded to 0; can be negative for refunds
+	Amount int `json:"amount"`
+
+	// Optional. The number of 1/1000000000 shares of Telegram Stars received by the affiliate; from -999999999 to 999999999; can be negative for refunds
+	NanostarAmount int `json:"nanostar_amount,omitempty"`
+}
+
+type transactionPartner struct {
+	// Type of the transaction partner
+	Type TransactionPartnerType `json:"type"`
+}
+
+// TransactionPartner describes the source of a transaction, or its recipient
+// for outgoing transactions. It is one of *TransactionPartnerUser,
+// *TransactionPartnerChat, *TransactionPartnerAffiliateProgram,
+// *TransactionPartnerFragment, *TransactionPartnerTelegramAds,
+// *TransactionPartnerTelegramAPI, *TransactionPartnerOther or, for a type
+// unknown to this package, *TransactionPartnerUnknown when decoded from JSON:
+//
+//	if user, ok := transaction.Source.(*tgbotapi.TransactionPartnerUser); ok {
+//		log.Println(user.User.ID, user.InvoicePayload)
+//	}
+//
+// https://core.telegram.org/bots/api#transactionpartner
+type TransactionPartner interface {
+	TransactionPartnerType() TransactionPartnerType
+}
+
+// unmarshalTransactionPartner decodes a TransactionPartner variant based on
+// its "type". Partners of a type unknown to this package are decoded as
+// *TransactionPartnerUnknown.
+func unmarshalTransactionPartner(data json.RawMessage) (TransactionPartner, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var head transactionPartner
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal TransactionPartner type: %w", err)
+	}
+	var partner TransactionPartner
+	switch head.Type {
+	case TransactionPartnerTypeUser:
+		partner = &TransactionPartnerUser{}
+	case TransactionPartnerTypeChat:
+		partner = &TransactionPartnerChat{}
+	case TransactionPartnerTypeAffiliateProgram:
+		partner = &TransactionPartnerAffiliateProgram{}
+	case TransactionPartnerTypeFragment:
+		partner = &TransactionPartnerFragment{}
+	case TransactionPartnerTypeTelegramAds:
+		partner = &TransactionPartnerTelegramAds{}
+	case TransactionPartnerTypeTelegramAPI:
+		partner = &TransactionPartnerTelegramAPI{}
+	case TransactionPartnerTypeOther:
+		partner = &TransactionPartnerOther{}
+	default:
+		return &TransactionPartnerUnknown{transactionPartner: head, Raw: data}, nil
+	}
+	if err := json.Unmarshal(data, partner); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal TransactionPartner of type %q: %w", head.Type, err)
+	}
+	return partner, nil
+}
+
+var _ TransactionPartner = (*TransactionPartnerUser)(nil)
+
+// TransactionPartnerUser describes a transaction with a user.
+// https://core.telegram.org/bots/api#transactionpartneruser
+type TransactionPartnerUser struct {
+	transactionPartner
+
+	// Type of the transaction, one of the TransactionType* constants
+	TransactionType string `json:"transaction_type"`
+
+	// Information about the user
+	User User `json:"user"`
+
+	// Optional. Information about the affiliate that received a commission via this transaction. Can be available only for "invoice_payment" and "paid_media_payment" transactions.
+	Affiliate *AffiliateInfo `json:"affiliate,omitempty"`
+
+	// Optional. Bot-specified invoice payload. Can be available only for "invoice_payment" transactions.
+	InvoicePayload string `json:"invoice_payload,omitempty"`
+
+	// Optional. The duration of the paid subscription. Can be available only for "invoice_payment" transactions.
+	SubscriptionPeriod int `json:"subscription_period,omitempty"`
+
+	// Optional. Information about the paid media bought by the user; for "paid_media_payment" transactions only
+	PaidMedia []PaidMedia `json:"paid_media,omitempty"`
+
+	// Optional. Bot-specified paid media payload. Can be available only for "paid_media_payment" transactions.
+	PaidMediaPayload string `json:"paid_media_payload,omitempty"`
+
+	// Optional. The gift sent to the user by the bot; for "gift_purchase" transactions only
+	Gift *Gift `json:"gift,omitempty"`
+
+	// Optional. Number of months the gifted Telegram Premium subscription will be active for; for "premium_purchase" transactions only
+	PremiumSubscriptionDuration int `json:"premium_subscription_duration,omitempty"`
+}
+
+func (TransactionPartnerUser) TransactionPartnerType() TransactionPartnerType {
+	return TransactionPartnerTypeUser
+}
+
+// MarshalJSON always encodes the "user" type.
+func (p TransactionPartnerUser) MarshalJSON() ([]byte, error) {
+	type alias TransactionPartnerUser
+	p.Type = TransactionPartnerTypeUser
+	return json.Marshal(alias(p))
+}
+
+var _ TransactionPartner = (*TransactionPartnerChat)(nil)
+
+// TransactionPartnerChat describes a transaction with a chat.
+// https://core.telegram.org/bots/api#transactionpartnerchat
+type TransactionPartnerChat struct {
+	transactionPartner
+
+	// Information about the chat
+	Chat Chat `json:"chat"`
+
+	// Optional. The gift sent to the chat by the bot
+	Gift *Gift `json:"gift,omitempty"`
+}
+
+func (TransactionPartnerChat) TransactionPartnerType() TransactionPartnerType {
+	return TransactionPartnerTypeChat
+}
+
+// MarshalJSON always encodes the "chat" type.
+func (p TransactionPartnerChat) MarshalJSON() ([]byte, error) {
+	type alias TransactionPartnerChat
+	p.Type = TransactionPartnerTypeChat
+	return json.Marshal(alias(p))
+}
+
+var _ TransactionPartner = (*TransactionPartnerAffiliateProgram)(nil)
+
+// TransactionPartnerAffiliateProgram describes the affiliate program that issued an affiliate commission received via this transaction.
+// https://core.telegram.org/bots/api#transactionpartneraffiliateprogram
+type TransactionPartnerAffiliateProgram struct {
+	transactionPartner
+
+	// Optional. Information about the bot that sponsored the affiliate program
+	SponsorUser *User `json:"sponsor_user,omitempty"`
+
+	// The number of Telegram Stars received by the bot for each 1000 Telegram Stars received by the affiliate program sponsor from referred users
+	CommissionPerMille int `json:"commission_per_mille"`
+}
+
+func (TransactionPartnerAffiliateProgram) TransactionPartnerType() TransactionPartnerType {
+	return TransactionPartnerTypeAffiliateProgram
+}
+
+// MarshalJSON always encodes the "affiliate_program" type.
+func (p TransactionPartnerAffiliateProgram) MarshalJSON() ([]byte, error) {
+	type alias TransactionPartnerAffiliateProgram
+	p.Type = TransactionPartnerTypeAffiliateProgram
+	return json.Marshal(alias(p))
+}
+
+var _ TransactionPartner = (*TransactionPartnerFragment)(nil)
+
+// TransactionPartnerFragment describes a withdrawal transaction with Fragment.
+// https://core.telegram.org/bots/api#transactionpartnerfragment
+type TransactionPartnerFragment struct {
+	transactionPartner
+
+	// Optional. State of the transaction if the transaction is outgoing
+	WithdrawalState *RevenueWithdrawalState `json:"withdrawal_state,omitempty"`
+}
+
+func (TransactionPartnerFragment) TransactionPartnerType() TransactionPartnerType {
+	return TransactionPartnerTypeFragment
+}
+
+// MarshalJSON always encodes the "fragment" type.
+func (p TransactionPartnerFragment) MarshalJSON() ([]byte, error) {
+	type alias TransactionPartnerFragment
+	p.Type = TransactionPartnerTypeFragment
+	return json.Marshal(alias(p))
+}
+
+var _ TransactionPartner = (*TransactionPartnerTelegramAds)(nil)
+
+// TransactionPartnerTelegramAds describes a withdrawal transaction to the Telegram Ads platform.
+// https://core.telegram.org/bots/api#transactionpartnertelegramads
+type TransactionPartnerTelegramAds struct {
+	transactionPartner
+}
+
+func (TransactionPartnerTelegramAds) TransactionPartnerType() TransactionPartnerType {
+	return TransactionPartnerTypeTelegramAds
+}
+
+// MarshalJSON always encodes the "telegram_ads" type.
+func (p TransactionPartnerTelegramAds) MarshalJSON() ([]byte, error) {
+	type alias TransactionPartnerTelegramAds
+	p.Type = TransactionPartnerTypeTelegramAds
+	return json.Marshal(alias(p))
+}
+
+var _ TransactionPartner = (*TransactionPartnerTelegramAPI)(nil)
+
+// TransactionPartnerTelegramAPI describes a transaction with payment for paid broadcasting.
+// https://core.telegram.org/bots/api#transactionpartnertelegramapi
+type TransactionPartnerTelegramAPI struct {
+	transactionPartner
+
+	// The number of successful requests that exceeded regular limits and were therefore billed
+	RequestCount int `json:"request_count"`
+}
+
+func (TransactionPartnerTelegramAPI) TransactionPartnerType() TransactionPartnerType {
+	return TransactionPartnerTypeTelegramAPI
+}
+
+// MarshalJSON always encodes the "telegram_api" type.
+func (p TransactionPartnerTelegramAPI) MarshalJSON() ([]byte, error) {
+	type alias TransactionPartnerTelegramAPI
+	p.Type = TransactionPartnerTypeTelegramAPI
+	return json.Marshal(alias(p))
+}
+
+var _ TransactionPartner = (*TransactionPartnerOther)(nil)
+
+// TransactionPartnerOther describes a transaction with an unknown source or recipient.
+// https://core.telegram.org/bots/api#transactionpartnerother
+type TransactionPartnerOther struct {
+	transactionPartner
+}
+
+func (TransactionPartnerOther) TransactionPartnerType() TransactionPartnerType {
+	return TransactionPartnerTypeOther
+}
+
+// MarshalJSON always encodes the "other" type.
+func (p TransactionPartnerOther) MarshalJSON() ([]byte, error) {
+	type alias TransactionPartnerOther
+	p.Type = TransactionPartnerTypeOther
+	return json.Marshal(alias(p))
+}
+
+var _ TransactionPartner = (*TransactionPartnerUnknown)(nil)
+
+// TransactionPartnerUnknown describes a transaction partner of a type this
+// package does not know yet. Raw holds the partner as received from Telegram.
+type TransactionPartnerUnknown struct {
+	transactionPartner
+
+	// Raw is the JSON object of the partner
+	Raw json.RawMessage `json:"-"`
+}
+
+func (p TransactionPartnerUnknown) TransactionPartnerType() TransactionPartnerType {
+	return p.Type
+}
+
+// MarshalJSON encodes the partner as it was received.
+func (p TransactionPartnerUnknown) MarshalJSON() ([]byte, error) {
+	if len(p.Raw) > 0 {
+		return p.Raw, nil
+	}
+	return json.Marshal(p.transactionPartner)
+}
+
+// StarTransaction describes a Telegram Star transaction. Source is set for
+// incoming transactions, e.g. a payment or a refund of an outgoing payment,
+// and Receiver for outgoing ones, e.g. a withdrawal or a refund.
+// https://core.telegram.org/bots/api#startransaction
+type StarTransaction struct {
+	// Unique identifier of the transaction. Coincides with the identifier of the original transaction for refund transactions. Coincides with SuccessfulPayment.telegram_payment_charge_id for successful incoming payments from users.
+	ID string `json:"id"`
+
+	// Integer amount of Telegram Stars transferred by the transaction
+	Amount int `json:"amount"`
+
+	// Optional. The number of 1/1000000000 shares of Telegram Stars transferred by the transaction; from 0 to 999999999
+	NanostarAmount int `json:"nanostar_amount,omitempty"`
+
+	// Date the transaction was created in Unix time
+	Date int `json:"date"`
+
+	// Optional. Source of an incoming transaction
+	Source TransactionPartner `json:"source,omitempty"`
+
+	// Optional. Receiver of an outgoing transaction
+	Receiver TransactionPartner `json:"receiver,omitempty"`
+
+	// incoming is set when the transaction was decoded with a source
+	incoming bool
+}
+
+// UnmarshalJSON decodes Source and Receiver into their TransactionPartner variants.
+func (t *StarTransaction) UnmarshalJSON(data []byte) error {
+	type alias StarTransaction
+	aux := struct {
+		*alias
+		Source   json.RawMessage `json:"source,omitempty"`
+		Receiver json.RawMessage `json:"receiver,omitempty"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.incoming = len(aux.Source) > 0 && string(aux.Source) != "null"
+	var err error
+	if t.Source, err = unmarshalTransactionPartner(aux.Source); err != nil {
+		return err
+	}
+	t.Receiver, err = unmarshalTransactionPartner(aux.Receiver)
+	return err
+}
+
+// IsIncoming reports whether the transaction was received by the bot, that
+// is whether it has a source, even one of a type unknown to this package.
+func (t StarTransaction) IsIncoming() bool {
+	return t.incoming || t.Source != nil
+}
+
+// StarTransactions contains a list of Telegram Star transactions.
+// https://core.telegram.org/bots/api#startransactions
+type StarTransactions struct {
+	// The list of transactions
+	Transactions []StarTransaction `json:"transactions"`
+}
+
+// GetStarTransactionsConfig contains information about a getStarTransactions request.
+// https://core.telegram.org/bots/api#getstartransactions
+type GetStarTransactionsConfig struct {
+	// Number of transactions to skip in the response
+	Offset int
+
+	// The maximum number of transactions to be retrieved. Values between 1-100 are accepted. Defaults to 100.
+	Limit int
+}
+
+// Values returns url.Values representation of GetStarTransactionsConfig.
+func (c GetStarTransactionsConfig) Values() (url.Values, error) {
+	if c.Offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+	if c.Limit < 0 || c.Limit > MaxStarTransactionsLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d, got %d", MaxStarTransactionsLimit, c.Limit)
+	}
+	values := url.Values{}
+	if c.Offset > 0 {
+		values.Add("offset", strconv.Itoa(c.Offset))
+	}
+	if c.Limit > 0 {
+		values.Add("limit", strconv.Itoa(c.Limit))
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for getting Star transactions.
+func (GetStarTransactionsConfig) TelegramMethod() string {
+	return "getStarTransactions"
+}
+
+// EditUserStarSubscriptionConfig contains information about an
+// editUserStarSubscription request that cancels or re-enables extension of a
+// subscription paid in Telegram Stars.
+// https://core.telegram.org/bots/api#edituserstarsubscription
+type EditUserStarSubscriptionConfig struct {
+	// Identifier of the user whose subscription will be edited
+	UserID int64
+
+	// Telegram payment identifier for the subscription
+	TelegramPaymentChargeID string
+
+	// Pass True to cancel extension of the user subscription; the subscription must be active up to the end of the current subscription period. Pass False to allow the user to re-enable a subscription that was previously canceled by the bot.
+	IsCanceled bool
+}
+
+// Values returns url.Values representation of EditUserStarSubscriptionConfig.
+func (c EditUserStarSubscriptionConfig) Values() (url.Values, error) {
+	if c.UserID == 0 {
+		return nil, errors.New("user_id is required")
+	}
+	if c.TelegramPaymentChargeID == "" {
+		return nil, errors.New("telegram_payment_charge_id is required")
+	}
+	values := url.Values{}
+	values.Add("user_id", strconv.FormatInt(c.UserID, 10))
+	values.Add("telegram_payment_charge_id", c.TelegramPaymentChargeID)
+	values.Add("is_canceled", strconv.FormatBool(c.IsCanceled))
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for editing a Star subscription.
+func (EditUserStarSubscriptionConfig) TelegramMethod() string {
+	return "editUserStarSubscription"
+}
+
+var (
+	_ Sendable = GetStarTransactionsConfig{}
+	_ Sendable = EditUserStarSubscriptionConfig{}
+)
+
+// GetStarTransactions returns the bot's Telegram Star transactions in
+// chronological order.
+func (bot *BotAPI) GetStarTransactions(config GetStarTransactionsConfig) (StarTransactions, error) {
+	return bot.GetStarTransactionsContext(context.Background(), config)
+}
+
+// GetStarTransactionsContext is GetStarTransactions using ctx for the HTTP call.
+func (bot *BotAPI) GetStarTransactionsContext(ctx context.Context, config GetStarTransactionsConfig) (transactions StarTransactions, err error) {
+	err = bot.SendCustomMessage(ctx, config, &transactions)
+	return
+}
+
+// AllStarTransactions iterates over all the bot's Telegram Star transactions,
+// requesting pages of pageSize transactions (MaxStarTransactionsLimit if 0)
+// by offset until a short page is returned. An error is yielded once and
+// stops the iteration.
+//
+//	for transaction, err := range bot.AllStarTransactions(ctx, 0) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (bot *BotAPI) AllStarTransactions(ctx context.Context, pageSize int) iter.Seq2[StarTransaction, error] {
+	if pageSize == 0 {
+		pageSize = MaxStarTransactionsLimit
+	}
+	return func(yield func(StarTransaction, error) bool) {
+		config := GetStarTransactionsConfig{Limit: pageSize}
+		for {
+			page, err := bot.GetStarTransactionsContext(ctx, config)
+			if err != nil {
+				yield(StarTransaction{}, fmt.Errorf("failed to get Star transactions at offset %d: %w", config.Offset, err))
+				return
+			}
+			for _, transaction := range page.Transactions {
+				if !yield(transaction, nil) {
+					return
+				}
+			}
+			if len(page.Transactions) < pageSize {
+				return
+			}
+			config.Offset += len(page.Transactions)
+		}
+	}
+}
+
+// GetMyStarBalance returns the current Telegram Stars balance of the bot.
+//
+// https://core.telegram.org/bots/api#getmystarbalance
+func (bot *BotAPI) GetMyStarBalance() (StarAmount, error) {
+	return bot.GetMyStarBalanceContext(context.Background())
+}
+
+// GetMyStarBalanceContext is GetMyStarBalance using ctx for the HTTP call.
+func (bot *BotAPI) GetMyStarBalanceContext(ctx context.Context) (balance StarAmount, err error) {
+	resp, err := bot.MakeRequestContext(ctx, "getMyStarBalance", nil)
+	if err != nil {
+		return balance, err
+	}
+	if err = json.Unmarshal(resp.Result, &balance); err != nil {
+		return balance, fmt.Errorf("failed to decode Telegram API response for method %q: %w", "getMyStarBalance", err)
+	}
+	return balance, nil
+}
+
+// EditUserStarSubscription cancels or re-enables extension of a subscription
+// paid in Telegram Stars.
+func (bot *BotAPI) EditUserStarSubscription(config EditUserStarSubscriptionConfig) (APIResponse, error) {
+	return bot.EditUserStarSubscriptionContext(context.Background(), config)
+}
+
+// EditUserStarSubscriptionContext is EditUserStarSubscription using ctx for the HTTP call.
+func (bot *BotAPI) EditUserStarSubscriptionContext(ctx context.Context, config EditUserStarSubscriptionConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
@@ -0,0 +1,149 @@
+package tgbotapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestStarTransaction_Unmarshal(t *testing.T) {
+	var transactions StarTransactions
+	err := json.Unmarshal([]byte(`{"transactions":[
+		{"id":"ch_1","amount":50,"date":1700000000,"source":{"type":"user","transaction_type":"invoice_payment","user":{"id":7,"first_name":"A"},"invoice_payload":"pro","subscription_period":2592000}},
+		{"id":"wd_1","amount":1000,"nanostar_amount":5,"date":1700000100,"receiver":{"type":"fragment","withdrawal_state":{"type":"succeeded","date":1700000200,"url":"https://fragment.com/tx"}}}
+	]}`), &transactions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payment, withdrawal := transactions.Transactions[0], transactions.Transactions[1]
+	user, ok := payment.Source.(*TransactionPartnerUser)
+	if !payment.IsIncoming() || !ok || user.TransactionType != TransactionTypeInvoicePayment || user.User.ID != 7 || user.SubscriptionPeriod != 2592000 {
+		t.Errorf("payment = %+v", payment.Source)
+	}
+	fragment, ok := withdrawal.Receiver.(*TransactionPartnerFragment)
+	if withdrawal.IsIncoming() || !ok || fragment.WithdrawalState == nil || fragment.WithdrawalState.Type != RevenueWithdrawalStateSucceeded {
+		t.Errorf("withdrawal = %+v", withdrawal.Receiver)
+	}
+
+	data, err := json.Marshal(payment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded StarTransaction
+	if err = json.Unmarshal(data, &decoded); err != nil || decoded.Source.TransactionPartnerType() != TransactionPartnerTypeUser {
+		t.Errorf("round trip = %s, err = %v", data, err)
+	}
+}
+
+func TestStarTransaction_UnknownPartner(t *testing.T) {
+	var transactions StarTransactions
+	err := json.Unmarshal([]byte(`{"transactions":[
+		{"id":"in_1","amount":30,"date":1700000000,"source":{"type":"space_station","station":"ISS"}},
+		{"id":"out_1","amount":20,"date":1700000100,"receiver":{"type":"space_station"}}
+	]}`), &transactions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	incoming, outgoing := transactions.Transactions[0], transactions.Transactions[1]
+	source, ok := incoming.Source.(*TransactionPartnerUnknown)
+	if !incoming.IsIncoming() || !ok || source.TransactionPartnerType() != "space_station" {
+		t.Fatalf("incoming = %+v", incoming)
+	}
+	if outgoing.IsIncoming() || outgoing.Receiver == nil || outgoing.Receiver.TransactionPartnerType() != "space_station" {
+		t.Errorf("outgoing = %+v", outgoing)
+	}
+	data, err := json.Marshal(incoming)
+	if err != nil || !strings.Contains(string(data), `"source":{"type":"space_station","station":"ISS"}`) {
+		t.Errorf("marshaled = %s, err = %v", data, err)
+	}
+}
+
+func TestStarConfigs_Values(t *testing.T) {
+	values, err := GetStarTransactionsConfig{Offset: 200, Limit: 50}.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Get("offset") != "200" || values.Get("limit") != "50" {
+		t.Errorf("values = %v", values)
+	}
+	if _, err = (GetStarTransactionsConfig{Limit: 101}).Values(); err == nil {
+		t.Error("expected an error for a limit over 100")
+	}
+
+	values, err = EditUserStarSubscriptionConfig{UserID: 7, TelegramPaymentChargeID: "ch_1"}.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Get("is_canceled") != "false" || values.Get("telegram_payment_charge_id") != "ch_1" {
+		t.Errorf("values = %v", values)
+	}
+	if _, err = (EditUserStarSubscriptionConfig{UserID: 7}).Values(); err == nil {
+		t.Error("expected an error without telegram_payment_charge_id")
+	}
+}
+
+func TestAllStarTransactions(t *testing.T) {
+	const total = 5
+	var offsets []string
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			offsets = append(offsets, r.PostForm.Get("offset"))
+			offset, _ := strconv.Atoi(r.PostForm.Get("offset"))
+			limit, _ := strconv.Atoi(r.PostForm.Get("limit"))
+			var page StarTransactions
+			for i := offset; i < min(offset+limit, total); i++ {
+				page.Transactions = append(page.Transactions, StarTransaction{ID: "tx" + strconv.Itoa(i), Amount: 1})
+			}
+			body, _ := json.Marshal(page)
+			body = []byte(`{"ok":true,"result":` + string(body) + `}`)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body))), Header: make(http.Header)}, nil
+		}),
+	})
+
+	var ids []string
+	for transaction, err := range bot.AllStarTransactions(context.Background(), 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, transaction.ID)
+	}
+	if strings.Join(ids, ",") != "tx0,tx1,tx2,tx3,tx4" {
+		t.Errorf("ids = %v", ids)
+	}
+	if strings.Join(offsets, ",") != ",2,4" {
+		t.Errorf("offsets = %q", offsets)
+	}
+
+	offsets = nil
+	for range bot.AllStarTransactions(context.Background(), 2) {
+		break
+	}
+	if len(offsets) != 1 {
+		t.Errorf("requested %d pages after break, want 1", len(offsets))
+	}
+}
+
+func TestGetMyStarBalance(t *testing.T) {
+	bot := testBotWithResponse("123456:TOKEN", http.StatusOK, `{"ok":true,"result":{"amount":1500,"nanostar_amount":250}}`)
+	balance, err := bot.GetMyStarBalance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Amount != 1500 || balance.NanostarAmount != 250 {
+		t.Errorf("balance = %+v", balance)
+	}
+
+	bot = testBotWithResponse("123456:TOKEN", http.StatusBadRequest, `{"ok":false,"error_code":400,"description":"Bad Request"}`)
+	for _, err := range bot.AllStarTransactions(context.Background(), 0) {
+		if err == nil || !strings.Contains(err.Error(), "offset 0") {
+			t.Errorf("err = %v", err)
+		}
+	}
+}
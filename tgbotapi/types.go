@@ -20,6 +20,20 @@ type APIResponse struct {
 	Result      json.RawMessage `json:"result"`
 	ErrorCode   int             `json:"error_code"`
 	Description string          `json:"description"`
+
+	// Optional. Describes why a request was unsuccessful and how it can be repeated.
+	Parameters *ResponseParameters `json:"parameters,omitempty"`
+}
+
+// ResponseParameters describes why a request was unsuccessful.
+// https://core.telegram.org/bots/api#responseparameters
+type ResponseParameters struct {
+	// Optional. The group has been migrated to a supergroup with the specified identifier.
+	MigrateToChatID int64 `json:"migrate_to_chat_id,omitempty"`
+
+	// Optional. In case of exceeding flood control, the number of seconds left to wait
+	// before the request can be repeated
+	RetryAfter int `json:"retry_after,omitempty"`
 }
 
 func (r APIResponse) Error() string {
@@ -84,43 +98,6 @@ type User struct {
 	SupportsJoinRequestQueries bool `json:"supports_join_request_queries,omitempty"`
 }
 
-// ChatMember holds information about chat member
-type ChatMember struct {
-	// Deprecated: use MemberUser. Kept for source compatibility with the
-	// framework's earlier, incomplete representation.
-	User
-	Status     string `json:"status,omitempty"`
-	MemberUser *User  `json:"user,omitempty"`
-	IsBot      bool   `json:"is_bot,omitempty"`
-
-	// Permission fields are populated for restricted members and administrators.
-	CanSendMessages       bool `json:"can_send_messages,omitempty"`
-	CanSendAudios         bool `json:"can_send_audios,omitempty"`
-	CanSendDocuments      bool `json:"can_send_documents,omitempty"`
-	CanSendPhotos         bool `json:"can_send_photos,omitempty"`
-	CanSendVideos         bool `json:"can_send_videos,omitempty"`
-	CanSendVideoNotes     bool `json:"can_send_video_notes,omitempty"`
-	CanSendVoiceNotes     bool `json:"can_send_voice_notes,omitempty"`
-	CanSendPolls          bool `json:"can_send_polls,omitempty"`
-	CanSendOtherMessages  bool `json:"can_send_other_messages,omitempty"`
-	CanAddWebPagePreviews bool `json:"can_add_web_page_previews,omitempty"`
-	CanReactToMessages    bool `json:"can_react_to_messages,omitempty"`
-	CanEditTag            bool `json:"can_edit_tag,omitempty"`
-	CanChangeInfo         bool `json:"can_change_info,omitempty"`
-	CanInviteUsers        bool `json:"can_invite_users,omitempty"`
-	CanPinMessages        bool `json:"can_pin_messages,omitempty"`
-	CanManageTopics       bool `json:"can_manage_topics,omitempty"`
-	UntilDate             int  `json:"until_date,omitempty"`
-}
-
-// IsBotUser indicates if chat member is a bot
-func (chatMember ChatMember) IsBotUser() bool {
-	if chatMember.MemberUser != nil {
-		return chatMember.MemberUser.IsBot
-	}
-	return chatMember.IsBot
-}
-
 // Platform returns 'Telegram'
 func (u User) Platform() string {
 	return "telegram"
@@ -230,6 +207,13 @@ type Chat struct {
 
 	// Optional. Community to which the chat belongs. Bot API 10.2+
 	Community *Community `json:"community,omitempty"`
+
+	// Optional. List of available reactions allowed in the chat; returned by getChat only.
+	// If omitted, then all emoji reactions are allowed.
+	AvailableReactions []ReactionType `json:"available_reactions,omitempty"`
+
+	// Optional. The maximum number of reactions that can be set on a message in the chat; returned by getChat only
+	MaxReactionCount int `json:"max_reaction_count,omitempty"`
 }
 
 // ChatFullInfo is the object returned by getChat. This package historically
@@ -419,10 +403,18 @@ type File struct {
 	FilePath string `json:"file_path,omitempty"` // optional
 }
 
-// Link returns a full path to the download URL for a File.
+// Link returns a full path to the download URL for a File on the cloud Bot
+// API server, or "" for a file stored by a local Bot API server.
+//
+// It requires the Bot Token to create the link, so the link must not be
+// logged or shared, see BotAPI.DownloadFile.
 //
-// It requires the Bot Token to create the link.
+// Deprecated: use BotAPI.FileURL, which honors BaseURL and TestEnvironment
+// and fails with ErrLocalFile for local files.
 func (f *File) Link(token string) string {
+	if f.IsLocal() {
+		return ""
+	}
 	return fmt.Sprintf(FileEndpoint, token, f.FilePath)
 }
 
@@ -86,25 +86,157 @@ type Update struct {
 	Subscription *BotSubscriptionUpdated `json:"subscription,omitempty"`
 }
 
-// Chat provides chat struct for the update
-func (update Update) Chat() *Chat {
+// Kind returns the type of the update, i.e. which optional field is set,
+// or an empty string for an update of a type unknown to this package.
+func (update Update) Kind() UpdateType {
 	switch {
 	case update.Message != nil:
-		return update.Message.Chat
+		return UpdateTypeMessage
 	case update.EditedMessage != nil:
-		return update.EditedMessage.Chat
+		return UpdateTypeEditedMessage
 	case update.ChannelPost != nil:
-		return update.ChannelPost.Chat
+		return UpdateTypeChannelPost
 	case update.EditedChannelPost != nil:
-		return update.EditedChannelPost.Chat
+		return UpdateTypeEditedChannelPost
+	case update.BusinessConnection != nil:
+		return UpdateTypeBusinessConnection
 	case update.BusinessMessage != nil:
-		return update.BusinessMessage.Chat
+		return UpdateTypeBusinessMessage
 	case update.EditedBusinessMessage != nil:
-		return update.EditedBusinessMessage.Chat
+		return UpdateTypeEditedBusinessMessage
+	case update.DeletedBusinessMessages != nil:
+		return UpdateTypeDeletedBusinessMessages
+	case update.MessageReaction != nil:
+		return UpdateTypeMessageReaction
+	case update.MessageReactionCount != nil:
+		return UpdateTypeMessageReactionCount
+	case update.InlineQuery != nil:
+		return UpdateTypeInlineQuery
+	case update.ChosenInlineResult != nil:
+		return UpdateTypeChosenInlineResult
 	case update.CallbackQuery != nil:
-		if update.CallbackQuery.Message != nil {
-			return update.CallbackQuery.Message.Chat
-		}
+		return UpdateTypeCallbackQuery
+	case update.ShippingQuery != nil:
+		return UpdateTypeShippingQuery
+	case update.PreCheckoutQuery != nil:
+		return UpdateTypePreCheckoutQuery
+	case update.PurchasedPaidMedia != nil:
+		return UpdateTypePurchasedPaidMedia
+	case update.Poll != nil:
+		return UpdateTypePoll
+	case update.PollAnswer != nil:
+		return UpdateTypePollAnswer
+	case update.MyChatMember != nil:
+		return UpdateTypeMyChatMember
+	case update.ChatMember != nil:
+		return UpdateTypeChatMember
+	case update.ChatJoinRequest != nil:
+		return UpdateTypeChatJoinRequest
+	case update.ChatBoost != nil:
+		return UpdateTypeChatBoost
+	case update.RemovedChatBoost != nil:
+		return UpdateTypeRemovedChatBoost
+	case update.ManagedBot != nil:
+		return UpdateTypeManagedBot
+	case update.GuestMessage != nil:
+		return UpdateTypeGuestMessage
+	case update.Subscription != nil:
+		return UpdateTypeSubscription
+	}
+	return ""
+}
+
+// EffectiveMessage returns the message the update carries, including the
+// message a callback query button was attached to, or nil.
+func (update Update) EffectiveMessage() *Message {
+	switch {
+	case update.Message != nil:
+		return update.Message
+	case update.EditedMessage != nil:
+		return update.EditedMessage
+	case update.ChannelPost != nil:
+		return update.ChannelPost
+	case update.EditedChannelPost != nil:
+		return update.EditedChannelPost
+	case update.BusinessMessage != nil:
+		return update.BusinessMessage
+	case update.EditedBusinessMessage != nil:
+		return update.EditedBusinessMessage
+	case update.GuestMessage != nil:
+		return update.GuestMessage
+	case update.CallbackQuery != nil:
+		return update.CallbackQuery.Message
+	}
+	return nil
+}
+
+// From returns the user that caused the update, or nil if the update has no
+// sender, e.g. a channel post, a poll or an anonymous reaction.
+func (update Update) From() *User {
+	if update.CallbackQuery != nil {
+		return update.CallbackQuery.From
+	}
+	if m := update.EffectiveMessage(); m != nil {
+		return m.From
+	}
+	switch {
+	case update.BusinessConnection != nil:
+		return &update.BusinessConnection.User
+	case update.MessageReaction != nil:
+		return update.MessageReaction.User
+	case update.InlineQuery != nil:
+		return update.InlineQuery.From
+	case update.ChosenInlineResult != nil:
+		return update.ChosenInlineResult.From
+	case update.ShippingQuery != nil:
+		return &update.ShippingQuery.From
+	case update.PreCheckoutQuery != nil:
+		return update.PreCheckoutQuery.From
+	case update.PurchasedPaidMedia != nil:
+		return &update.PurchasedPaidMedia.From
+	case update.PollAnswer != nil:
+		return update.PollAnswer.User
+	case update.MyChatMember != nil:
+		return &update.MyChatMember.From
+	case update.ChatMember != nil:
+		return &update.ChatMember.From
+	case update.ChatJoinRequest != nil:
+		return &update.ChatJoinRequest.From
+	case update.ChatBoost != nil:
+		return update.ChatBoost.Boost.Source.User
+	case update.RemovedChatBoost != nil:
+		return update.RemovedChatBoost.Source.User
+	case update.ManagedBot != nil:
+		return &update.ManagedBot.User
+	case update.Subscription != nil:
+		return &update.Subscription.User
+	}
+	return nil
+}
+
+// Chat returns the chat the update belongs to, or nil if there is none, e.g.
+// for inline queries, payments and subscriptions.
+func (update Update) Chat() *Chat {
+	if m := update.EffectiveMessage(); m != nil {
+		return m.Chat
+	}
+	switch {
+	case update.DeletedBusinessMessages != nil:
+		return &update.DeletedBusinessMessages.Chat
+	case update.MessageReaction != nil:
+		return &update.MessageReaction.Chat
+	case update.MessageReactionCount != nil:
+		return &update.MessageReactionCount.Chat
+	case update.MyChatMember != nil:
+		return &update.MyChatMember.Chat
+	case update.ChatMember != nil:
+		return &update.ChatMember.Chat
+	case update.ChatJoinRequest != nil:
+		return &update.ChatJoinRequest.Chat
+	case update.ChatBoost != nil:
+		return &update.ChatBoost.Chat
+	case update.RemovedChatBoost != nil:
+		return &update.RemovedChatBoost.Chat
 	}
 	return nil
 }
@@ -0,0 +1,83 @@
+package tgbotapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUpdate_Accessors(t *testing.T) {
+	user := &User{ID: 1}
+	chat := &Chat{ID: -100}
+	message := &Message{MessageID: 5, From: user, Chat: chat}
+
+	tests := []struct {
+		update  Update
+		kind    UpdateType
+		from    *User
+		chat    *Chat
+		message *Message
+	}{
+		{Update{Message: message}, UpdateTypeMessage, user, chat, message},
+		{Update{BusinessMessage: message}, UpdateTypeBusinessMessage, user, chat, message},
+		{Update{GuestMessage: message}, UpdateTypeGuestMessage, user, chat, message},
+		{Update{CallbackQuery: &CallbackQuery{From: &User{ID: 2}, Message: message}}, UpdateTypeCallbackQuery, &User{ID: 2}, chat, message},
+		{Update{BusinessConnection: &BusinessConnection{User: *user}}, UpdateTypeBusinessConnection, user, nil, nil},
+		{Update{DeletedBusinessMessages: &BusinessMessagesDeleted{Chat: *chat}}, UpdateTypeDeletedBusinessMessages, nil, chat, nil},
+		{Update{MessageReaction: &MessageReactionUpdated{Chat: *chat, User: user}}, UpdateTypeMessageReaction, user, chat, nil},
+		{Update{MessageReactionCount: &MessageReactionCountUpdated{Chat: *chat}}, UpdateTypeMessageReactionCount, nil, chat, nil},
+		{Update{InlineQuery: &InlineQuery{From: user}}, UpdateTypeInlineQuery, user, nil, nil},
+		{Update{PreCheckoutQuery: &PreCheckoutQuery{From: user}}, UpdateTypePreCheckoutQuery, user, nil, nil},
+		{Update{PollAnswer: &PollAnswer{User: user}}, UpdateTypePollAnswer, user, nil, nil},
+		{Update{ChatMember: &ChatMemberUpdated{Chat: *chat, From: *user}}, UpdateTypeChatMember, user, chat, nil},
+		{Update{ChatJoinRequest: &ChatJoinRequest{Chat: *chat, From: *user}}, UpdateTypeChatJoinRequest, user, chat, nil},
+		{Update{ChatBoost: &ChatBoostUpdated{Chat: *chat, Boost: ChatBoost{Source: ChatBoostSource{User: user}}}}, UpdateTypeChatBoost, user, chat, nil},
+		{Update{RemovedChatBoost: &ChatBoostRemoved{Chat: *chat}}, UpdateTypeRemovedChatBoost, nil, chat, nil},
+		{Update{Subscription: &BotSubscriptionUpdated{User: *user}}, UpdateTypeSubscription, user, nil, nil},
+		{Update{UpdateID: 1}, "", nil, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			if got := tt.update.Kind(); got != tt.kind {
+				t.Errorf("Kind() = %q, want %q", got, tt.kind)
+			}
+			if got := tt.update.From(); !reflect.DeepEqual(got, tt.from) {
+				t.Errorf("From() = %+v, want %+v", got, tt.from)
+			}
+			if got := tt.update.Chat(); !reflect.DeepEqual(got, tt.chat) {
+				t.Errorf("Chat() = %+v, want %+v", got, tt.chat)
+			}
+			if got := tt.update.EffectiveMessage(); got != tt.message {
+				t.Errorf("EffectiveMessage() = %+v, want %+v", got, tt.message)
+			}
+		})
+	}
+}
+
+// TestUpdateType_MatchesJSONFields guards against UpdateType values drifting
+// from the JSON names of the Update fields.
+func TestUpdateType_MatchesJSONFields(t *testing.T) {
+	updateType := reflect.TypeOf(Update{})
+	var fields []UpdateType
+	for i := 0; i < updateType.NumField(); i++ {
+		name := strings.Split(updateType.Field(i).Tag.Get("json"), ",")[0]
+		if name != "update_id" {
+			fields = append(fields, UpdateType(name))
+		}
+	}
+	if !reflect.DeepEqual(fields, AllUpdateTypes) {
+		t.Fatalf("AllUpdateTypes = %v, want %v", AllUpdateTypes, fields)
+	}
+
+	var update Update
+	if err := json.Unmarshal([]byte(`{"update_id":1,"removed_chat_boost":{"chat":{"id":1},"boost_id":"b"}}`), &update); err != nil {
+		t.Fatal(err)
+	}
+	if update.Kind() != UpdateTypeRemovedChatBoost {
+		t.Fatalf("Kind() = %q", update.Kind())
+	}
+	if got := AllowedUpdates(UpdateTypeMessage, UpdateTypeChatMember); !reflect.DeepEqual(got, []string{"message", "chat_member"}) {
+		t.Fatalf("AllowedUpdates() = %v", got)
+	}
+}
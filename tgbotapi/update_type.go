@@ -0,0 +1,76 @@
+package tgbotapi
+
+// UpdateType identifies which optional field of an Update is set. The values
+// match the update type names accepted by allowed_updates.
+// https://core.telegram.org/bots/api#update
+type UpdateType string
+
+const (
+	UpdateTypeMessage                 UpdateType = "message"
+	UpdateTypeEditedMessage           UpdateType = "edited_message"
+	UpdateTypeChannelPost             UpdateType = "channel_post"
+	UpdateTypeEditedChannelPost       UpdateType = "edited_channel_post"
+	UpdateTypeBusinessConnection      UpdateType = "business_connection"
+	UpdateTypeBusinessMessage         UpdateType = "business_message"
+	UpdateTypeEditedBusinessMessage   UpdateType = "edited_business_message"
+	UpdateTypeDeletedBusinessMessages UpdateType = "deleted_business_messages"
+	UpdateTypeMessageReaction         UpdateType = "message_reaction"
+	UpdateTypeMessageReactionCount    UpdateType = "message_reaction_count"
+	UpdateTypeInlineQuery             UpdateType = "inline_query"
+	UpdateTypeChosenInlineResult      UpdateType = "chosen_inline_result"
+	UpdateTypeCallbackQuery           UpdateType = "callback_query"
+	UpdateTypeShippingQuery           UpdateType = "shipping_query"
+	UpdateTypePreCheckoutQuery        UpdateType = "pre_checkout_query"
+	UpdateTypePurchasedPaidMedia      UpdateType = "purchased_paid_media"
+	UpdateTypePoll                    UpdateType = "poll"
+	UpdateTypePollAnswer              UpdateType = "poll_answer"
+	UpdateTypeMyChatMember            UpdateType = "my_chat_member"
+	UpdateTypeChatMember              UpdateType = "chat_member"
+	UpdateTypeChatJoinRequest         UpdateType = "chat_join_request"
+	UpdateTypeChatBoost               UpdateType = "chat_boost"
+	UpdateTypeRemovedChatBoost        UpdateType = "removed_chat_boost"
+	UpdateTypeManagedBot              UpdateType = "managed_bot"
+	UpdateTypeGuestMessage            UpdateType = "guest_message"
+	UpdateTypeSubscription            UpdateType = "subscription"
+)
+
+// AllUpdateTypes lists every update type, e.g. to opt in to chat_member and
+// reaction updates that Telegram does not send by default.
+var AllUpdateTypes = []UpdateType{
+	UpdateTypeMessage,
+	UpdateTypeEditedMessage,
+	UpdateTypeChannelPost,
+	UpdateTypeEditedChannelPost,
+	UpdateTypeBusinessConnection,
+	UpdateTypeBusinessMessage,
+	UpdateTypeEditedBusinessMessage,
+	UpdateTypeDeletedBusinessMessages,
+	UpdateTypeMessageReaction,
+	UpdateTypeMessageReactionCount,
+	UpdateTypeInlineQuery,
+	UpdateTypeChosenInlineResult,
+	UpdateTypeCallbackQuery,
+	UpdateTypeShippingQuery,
+	UpdateTypePreCheckoutQuery,
+	UpdateTypePurchasedPaidMedia,
+	UpdateTypePoll,
+	UpdateTypePollAnswer,
+	UpdateTypeMyChatMember,
+	UpdateTypeChatMember,
+	UpdateTypeChatJoinRequest,
+	UpdateTypeChatBoost,
+	UpdateTypeRemovedChatBoost,
+	UpdateTypeManagedBot,
+	UpdateTypeGuestMessage,
+	UpdateTypeSubscription,
+}
+
+// AllowedUpdates converts update types to the []string used by
+// UpdateConfig.AllowedUpdates and WebhookConfig.AllowedUpdates.
+func AllowedUpdates(types ...UpdateType) []string {
+	allowed := make([]string, len(types))
+	for i, t := range types {
+		allowed[i] = string(t)
+	}
+	return allowed
+}
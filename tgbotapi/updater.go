@@ -0,0 +1,166 @@
+package tgbotapi
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = time.Minute
+)
+
+// Updater long-polls getUpdates and delivers updates on a channel until the
+// context passed to Start is cancelled.
+//
+// The channel is unbuffered, so the offset advances only past updates that
+// were actually received from it. On shutdown the Updater makes one last
+// getUpdates call to confirm those updates to Telegram, so they are not
+// redelivered, while updates that were fetched but not received stay pending
+// for the next run.
+type Updater struct {
+	bot    *BotAPI
+	config UpdateConfig
+
+	// MinBackoff and MaxBackoff bound the exponential backoff between failed
+	// getUpdates calls. They default to 1 second and 1 minute, also when zero.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// OnError, if set, is called for every failed getUpdates call with the
+	// delay before the next attempt. It is called from the polling goroutine.
+	OnError func(err error, retryIn time.Duration)
+
+	// ShutdownTimeout bounds the final getUpdates call that confirms delivered
+	// updates. Defaults to 5 seconds.
+	ShutdownTimeout time.Duration
+
+	mu     sync.Mutex
+	offset int
+}
+
+// NewUpdater creates an Updater that polls with a copy of config.
+//
+// config.Offset is the first update to request. Set config.Timeout to enable
+// long polling and config.AllowedUpdates to filter update types.
+func NewUpdater(bot *BotAPI, config UpdateConfig) *Updater {
+	return &Updater{
+		bot:             bot,
+		config:          config,
+		MinBackoff:      defaultMinBackoff,
+		MaxBackoff:      defaultMaxBackoff,
+		ShutdownTimeout: 5 * time.Second,
+		offset:          config.Offset,
+	}
+}
+
+// Offset returns the offset to resume polling from: one higher than the ID of
+// the last update received from the channel.
+func (u *Updater) Offset() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.offset
+}
+
+func (u *Updater) setOffset(offset int) {
+	u.mu.Lock()
+	u.offset = offset
+	u.mu.Unlock()
+}
+
+// Start begins polling in a new goroutine. The returned channel is closed
+// once ctx is cancelled and the delivered updates have been confirmed.
+func (u *Updater) Start(ctx context.Context) <-chan Update {
+	updates := make(chan Update)
+	go u.run(ctx, updates)
+	return updates
+}
+
+func (u *Updater) run(ctx context.Context, updates chan<- Update) {
+	defer close(updates)
+
+	config := u.config
+	confirmed := u.Offset()
+	defer func() {
+		u.confirm(ctx, confirmed)
+	}()
+
+	for failures := 0; ; {
+		config.Offset = u.Offset()
+		batch, err := u.bot.GetUpdatesContext(ctx, &config)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			failures++
+			delay := u.backoff(failures, err)
+			if u.OnError != nil {
+				u.OnError(err, delay)
+			}
+			if u.bot.sleepContext(ctx, delay) != nil {
+				return
+			}
+			continue
+		}
+		failures = 0
+		confirmed = config.Offset
+
+		for _, update := range batch {
+			if update.UpdateID < config.Offset {
+				continue
+			}
+			select {
+			case updates <- update:
+				u.setOffset(update.UpdateID + 1)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// confirm acknowledges updates delivered after the last successful poll.
+func (u *Updater) confirm(ctx context.Context, confirmed int) {
+	offset := u.Offset()
+	if offset <= confirmed {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), u.ShutdownTimeout)
+	defer cancel()
+	// limit=1 without timeout returns at most one update, which stays unconfirmed.
+	config := UpdateConfig{Offset: offset, Limit: 1}
+	if _, err := u.bot.GetUpdatesContext(ctx, &config); err != nil && u.OnError != nil {
+		u.OnError(err, 0)
+	}
+}
+
+// backoff returns the delay before the next attempt after failures consecutive
+// errors: exponential between MinBackoff and MaxBackoff with equal jitter, but
+// never shorter than Telegram's retry_after.
+func (u *Updater) backoff(failures int, err error) time.Duration {
+	minBackoff, maxBackoff := u.MinBackoff, u.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultMinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	maxBackoff = max(maxBackoff, minBackoff)
+
+	delay := minBackoff
+	for i := 1; i < failures && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	if delay > 1 {
+		delay = delay/2 + rand.N(delay/2)
+	}
+	if details, ok := TelegramProviderErrorDetailsFrom(err); ok && details.RetryAfter > delay {
+		delay = details.RetryAfter
+	}
+	return delay
+}
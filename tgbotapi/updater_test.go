@@ -0,0 +1,163 @@
+package tgbotapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pollingTransport serves scripted getUpdates batches and then blocks long-poll
+// requests until they are cancelled. Requests without a timeout are answered
+// with an empty result immediately.
+type pollingTransport struct {
+	mu       sync.Mutex
+	batches  []string
+	requests []pollRequest
+}
+
+type pollRequest struct {
+	offset         string
+	limit          string
+	timeout        string
+	allowedUpdates string
+}
+
+func (p *pollingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	req := pollRequest{
+		offset:         r.PostForm.Get("offset"),
+		limit:          r.PostForm.Get("limit"),
+		timeout:        r.PostForm.Get("timeout"),
+		allowedUpdates: r.PostForm.Get("allowed_updates"),
+	}
+	p.mu.Lock()
+	p.requests = append(p.requests, req)
+	var body string
+	if len(p.batches) > 0 {
+		body, p.batches = p.batches[0], p.batches[1:]
+	}
+	p.mu.Unlock()
+
+	if body == "" {
+		if req.timeout != "" {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		}
+		body = `{"ok":true,"result":[]}`
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func (p *pollingTransport) recorded() []pollRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]pollRequest(nil), p.requests...)
+}
+
+func TestUpdater_DeliversAndConfirmsOnShutdown(t *testing.T) {
+	transport := &pollingTransport{batches: []string{
+		`{"ok":true,"result":[{"update_id":10},{"update_id":11},{"update_id":12}]}`,
+	}}
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{Transport: transport})
+	config := UpdateConfig{Timeout: 30, AllowedUpdates: []string{"message", "callback_query"}}
+	updater := NewUpdater(bot, config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := updater.Start(ctx)
+
+	for _, want := range []int{10, 11} {
+		if got := (<-updates).UpdateID; got != want {
+			t.Fatalf("UpdateID = %d, want %d", got, want)
+		}
+	}
+	cancel()
+	lastReceived := 11
+	for update := range updates {
+		// update 12 may still be handed over while shutting down
+		lastReceived = update.UpdateID
+	}
+
+	wantOffset := lastReceived + 1
+	if got := updater.Offset(); got != wantOffset {
+		t.Fatalf("Offset() = %d, want %d", got, wantOffset)
+	}
+	if config.Offset != 0 {
+		t.Fatal("Updater mutated the caller's UpdateConfig")
+	}
+	requests := transport.recorded()
+	if requests[0].allowedUpdates != `["message","callback_query"]`+"\n" {
+		t.Fatalf("allowed_updates = %q", requests[0].allowedUpdates)
+	}
+	last := requests[len(requests)-1]
+	if last.offset != strconv.Itoa(wantOffset) || last.limit != "1" || last.timeout != "" {
+		t.Fatalf("final confirm request = %+v, want offset=%d limit=1 without timeout", last, wantOffset)
+	}
+}
+
+func TestUpdater_BacksOffAndReportsErrors(t *testing.T) {
+	transport := &pollingTransport{batches: []string{
+		`{"ok":false,"error_code":502,"description":"Bad Gateway"}`,
+		`{"ok":false,"error_code":429,"description":"Too Many Requests","parameters":{"retry_after":7}}`,
+		`{"ok":true,"result":[{"update_id":1}]}`,
+	}}
+	bot := NewBotAPIWithClient("123456:TOKEN", &http.Client{Transport: transport})
+	var slept []time.Duration
+	bot.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	updater := NewUpdater(bot, UpdateConfig{Timeout: 30})
+	var reported []error
+	updater.OnError = func(err error, retryIn time.Duration) {
+		reported = append(reported, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := updater.Start(ctx)
+	if got := (<-updates).UpdateID; got != 1 {
+		t.Fatalf("UpdateID = %d, want 1", got)
+	}
+	cancel()
+	for range updates {
+	}
+
+	if len(reported) != 2 {
+		t.Fatalf("OnError called %d times, want 2", len(reported))
+	}
+	if !errors.Is(reported[1], ErrTooManyRequests) {
+		t.Fatalf("second error = %v, want ErrTooManyRequests", reported[1])
+	}
+	if slept[0] < 500*time.Millisecond || slept[0] > time.Second {
+		t.Fatalf("first backoff = %v, want jittered value in [0.5s, 1s]", slept[0])
+	}
+	if slept[1] != 7*time.Second {
+		t.Fatalf("second backoff = %v, want retry_after 7s", slept[1])
+	}
+}
+
+func TestUpdater_Backoff(t *testing.T) {
+	updater := NewUpdater(NewBotAPI("123456:TOKEN"), UpdateConfig{})
+	for failures, max := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 20: time.Minute} {
+		got := updater.backoff(failures, errors.New("boom"))
+		if got < max/2 || got > max {
+			t.Errorf("backoff(%d) = %v, want within [%v, %v]", failures, got, max/2, max)
+		}
+	}
+
+	updater.MinBackoff, updater.MaxBackoff = 0, 0
+	if got := updater.backoff(1, errors.New("boom")); got < defaultMinBackoff/2 {
+		t.Errorf("backoff without MinBackoff = %v, want at least %v", got, defaultMinBackoff/2)
+	}
+}
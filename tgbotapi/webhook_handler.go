@@ -0,0 +1,162 @@
+package tgbotapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/strongo/logus"
+)
+
+// SecretTokenHeader is the header Telegram uses to send WebhookConfig.SecretToken.
+const SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// DefaultWebhookMaxBodyBytes is the default limit on the size of a webhook request body.
+const DefaultWebhookMaxBodyBytes = 1 << 20
+
+// WebhookUpdateHandler processes an update received by WebhookHandler.
+//
+// A non-nil reply is sent back to Telegram in the webhook response body, see
+// ReplyToResponse. Returning an error makes the handler answer with
+// 500 Internal Server Error, so Telegram delivers the update again later.
+type WebhookUpdateHandler func(ctx context.Context, update Update) (reply Sendable, err error)
+
+var _ http.Handler = (*WebhookHandler)(nil)
+
+// WebhookHandler is an http.Handler for updates pushed by Telegram to a webhook.
+//
+// It is not registered on any mux; mount it wherever the webhook URL points to:
+//
+//	handler := tgbotapi.NewWebhookHandler(secretToken, handleUpdate)
+//	mux.Handle("/telegram/webhook", handler)
+type WebhookHandler struct {
+	// SecretToken must match the X-Telegram-Bot-Api-Secret-Token header,
+	// i.e. the secret_token passed to setWebhook. Empty disables the check.
+	SecretToken string
+
+	// MaxBodyBytes limits the request body. Defaults to DefaultWebhookMaxBodyBytes.
+	MaxBodyBytes int64
+
+	// EnqueueTimeout is how long a request waits for room in the updates channel
+	// before answering 503 Service Unavailable. Only used in channel mode.
+	EnqueueTimeout time.Duration
+
+	handle  WebhookUpdateHandler
+	updates chan Update
+}
+
+// NewWebhookHandler creates a WebhookHandler that calls handle synchronously
+// for every update.
+func NewWebhookHandler(secretToken string, handle WebhookUpdateHandler) *WebhookHandler {
+	if handle == nil {
+		panic("handle must not be nil")
+	}
+	return &WebhookHandler{
+		SecretToken:  secretToken,
+		MaxBodyBytes: DefaultWebhookMaxBodyBytes,
+		handle:       handle,
+	}
+}
+
+// NewWebhookChannelHandler creates a WebhookHandler that delivers updates to
+// the returned channel with the given buffer size.
+func NewWebhookChannelHandler(secretToken string, buffer int) (*WebhookHandler, <-chan Update) {
+	updates := make(chan Update, buffer)
+	return &WebhookHandler{
+		SecretToken:    secretToken,
+		MaxBodyBytes:   DefaultWebhookMaxBodyBytes,
+		EnqueueTimeout: 10 * time.Second,
+		updates:        updates,
+	}, updates
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.isSecretTokenValid(r.Header.Get(SecretTokenHeader)) {
+		http.Error(w, "Invalid secret token", http.StatusUnauthorized)
+		return
+	}
+
+	maxBodyBytes := h.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultWebhookMaxBodyBytes
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		logus.Warningf(ctx, "Failed to read Telegram webhook request body: error_type=%T", err)
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	var update Update
+	if err = json.Unmarshal(body, &update); err != nil {
+		logus.Warningf(ctx, "Failed to decode Telegram webhook update: body_bytes=%d, error_type=%T", len(body), err)
+		http.Error(w, "Invalid update JSON", http.StatusBadRequest)
+		return
+	}
+
+	if h.handle == nil {
+		h.enqueue(w, r, update)
+		return
+	}
+
+	reply, err := h.handle(ctx, update)
+	if err != nil {
+		logus.Errorf(ctx, "Telegram webhook handler failed: update_id=%d, error_type=%T", update.UpdateID, err)
+		http.Error(w, "Failed to process update", http.StatusInternalServerError)
+		return
+	}
+	if reply == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if _, err = ReplyToResponse(reply, w); err != nil {
+		logus.Errorf(ctx, "Failed to write Telegram webhook reply: method=%q, error_type=%T", reply.TelegramMethod(), err)
+		http.Error(w, "Failed to write reply", http.StatusInternalServerError)
+	}
+}
+
+func (h *WebhookHandler) enqueue(w http.ResponseWriter, r *http.Request, update Update) {
+	var timeout <-chan time.Time
+	if h.EnqueueTimeout > 0 {
+		timer := time.NewTimer(h.EnqueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case h.updates <- update:
+		w.WriteHeader(http.StatusOK)
+	case <-timeout:
+		logus.Warningf(r.Context(), "Telegram webhook updates channel is full: update_id=%d", update.UpdateID)
+		http.Error(w, "Updates queue is full", http.StatusServiceUnavailable)
+	case <-r.Context().Done():
+		http.Error(w, "Request cancelled", http.StatusServiceUnavailable)
+	}
+}
+
+// isSecretTokenValid compares digests so that neither the content nor the
+// length of the secret leaks through timing.
+func (h *WebhookHandler) isSecretTokenValid(received string) bool {
+	if h.SecretToken == "" {
+		return true
+	}
+	expected := sha256.Sum256([]byte(h.SecretToken))
+	actual := sha256.Sum256([]byte(received))
+	return subtle.ConstantTimeCompare(expected[:], actual[:]) == 1
+}
@@ -0,0 +1,92 @@
+package tgbotapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newWebhookRequest(body, secretToken string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	if secretToken != "" {
+		r.Header.Set(SecretTokenHeader, secretToken)
+	}
+	return r
+}
+
+func TestWebhookHandler_Status(t *testing.T) {
+	okHandler := func(context.Context, Update) (Sendable, error) { return nil, nil }
+	tests := []struct {
+		name    string
+		request *http.Request
+		handle  WebhookUpdateHandler
+		want    int
+	}{
+		{"ok", newWebhookRequest(`{"update_id":1}`, "s3cret"), okHandler, http.StatusOK},
+		{"wrong secret", newWebhookRequest(`{"update_id":1}`, "wrong"), okHandler, http.StatusUnauthorized},
+		{"missing secret", newWebhookRequest(`{"update_id":1}`, ""), okHandler, http.StatusUnauthorized},
+		{"method", httptest.NewRequest(http.MethodGet, "/webhook", nil), okHandler, http.StatusMethodNotAllowed},
+		{"invalid JSON", newWebhookRequest(`{`, "s3cret"), okHandler, http.StatusBadRequest},
+		{"too large", newWebhookRequest(`{"update_id":1,"x":"`+strings.Repeat("a", 2<<20)+`"}`, "s3cret"), okHandler, http.StatusRequestEntityTooLarge},
+		{"handler error", newWebhookRequest(`{"update_id":1}`, "s3cret"), func(context.Context, Update) (Sendable, error) {
+			return nil, errors.New("boom")
+		}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			NewWebhookHandler("s3cret", tt.handle).ServeHTTP(w, tt.request)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_InlineReply(t *testing.T) {
+	handler := NewWebhookHandler("", func(_ context.Context, update Update) (Sendable, error) {
+		return NewMessage(update.Message.Chat.ID, "pong"), nil
+	})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newWebhookRequest(`{"update_id":1,"message":{"message_id":2,"date":0,"chat":{"id":42,"type":"private"},"text":"ping"}}`, ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	values, err := url.ParseQuery(w.Body.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Get("method") != "sendMessage" || values.Get("chat_id") != "42" || values.Get("text") != "pong" {
+		t.Fatalf("reply = %v", values)
+	}
+}
+
+func TestWebhookChannelHandler(t *testing.T) {
+	handler, updates := NewWebhookChannelHandler("", 1)
+	handler.EnqueueTimeout = 10 * time.Millisecond
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newWebhookRequest(`{"update_id":7}`, ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newWebhookRequest(`{"update_id":8}`, ""))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status with full channel = %d, want 503", w.Code)
+	}
+
+	if update := <-updates; update.UpdateID != 7 {
+		t.Fatalf("UpdateID = %d, want 7", update.UpdateID)
+	}
+}
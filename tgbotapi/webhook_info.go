@@ -0,0 +1,190 @@
+package tgbotapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/strongo/logus"
+)
+
+// DefaultWebhookMaxConnections is the max_connections Telegram uses when
+// setWebhook is called without one.
+const DefaultWebhookMaxConnections = 40
+
+// WebhookInfo describes the current status of a webhook.
+//
+// https://core.telegram.org/bots/api#webhookinfo
+type WebhookInfo struct {
+	// Webhook URL, may be empty if webhook is not set up
+	URL string `json:"url"`
+
+	// True, if a custom certificate was provided for webhook certificate checks
+	HasCustomCertificate bool `json:"has_custom_certificate"`
+
+	// Number of updates awaiting delivery
+	PendingUpdateCount int `json:"pending_update_count"`
+
+	// Optional. Currently used webhook IP address
+	IPAddress string `json:"ip_address,omitempty"`
+
+	// Optional. Unix time for the most recent error that happened when trying to deliver an update via webhook
+	LastErrorDate int64 `json:"last_error_date,omitempty"`
+
+	// Optional. Error message in human-readable format for the most recent error that happened when trying to deliver an update via webhook
+	LastErrorMessage string `json:"last_error_message,omitempty"`
+
+	// Optional. Unix time of the most recent error that happened when trying to synchronize available updates with Telegram datacenters
+	LastSynchronizationErrorDate int64 `json:"last_synchronization_error_date,omitempty"`
+
+	// Optional. The maximum allowed number of simultaneous HTTPS connections to the webhook for update delivery
+	MaxConnections int `json:"max_connections,omitempty"`
+
+	// Optional. A list of update types the bot is subscribed to. Defaults to all update types except chat_member
+	AllowedUpdates []string `json:"allowed_updates,omitempty"`
+}
+
+// IsSet reports whether a webhook is set up.
+func (info WebhookInfo) IsSet() bool {
+	return info.URL != ""
+}
+
+// DeleteWebhookConfig contains information about a deleteWebhook request.
+//
+// https://core.telegram.org/bots/api#deletewebhook
+type DeleteWebhookConfig struct {
+	// Pass True to drop all pending updates
+	DropPendingUpdates bool `json:"drop_pending_updates,omitempty"`
+}
+
+// Values returns url.Values representation of DeleteWebhookConfig.
+func (v DeleteWebhookConfig) Values() (url.Values, error) {
+	values := url.Values{}
+	if v.DropPendingUpdates {
+		values.Add("drop_pending_updates", "true")
+	}
+	return values, nil
+}
+
+// TelegramMethod returns Telegram API method name for deleting a webhook.
+func (DeleteWebhookConfig) TelegramMethod() string {
+	return "deleteWebhook"
+}
+
+// DeleteWebhook removes the webhook integration, switching the bot back to getUpdates.
+func (bot *BotAPI) DeleteWebhook(config DeleteWebhookConfig) (APIResponse, error) {
+	return bot.DeleteWebhookContext(context.Background(), config)
+}
+
+// DeleteWebhookContext is DeleteWebhook using ctx for the HTTP call.
+func (bot *BotAPI) DeleteWebhookContext(ctx context.Context, config DeleteWebhookConfig) (APIResponse, error) {
+	return bot.MakeRequestFromChattableContext(ctx, config)
+}
+
+// GetWebhookInfo returns the current status of the webhook.
+func (bot *BotAPI) GetWebhookInfo() (WebhookInfo, error) {
+	return bot.GetWebhookInfoContext(context.Background())
+}
+
+// GetWebhookInfoContext is GetWebhookInfo using ctx for the HTTP call.
+func (bot *BotAPI) GetWebhookInfoContext(ctx context.Context) (info WebhookInfo, err error) {
+	resp, err := bot.MakeRequestContext(ctx, "getWebhookInfo", url.Values{})
+	if err != nil {
+		return info, err
+	}
+	if err = json.Unmarshal(resp.Result, &info); err != nil {
+		return info, fmt.Errorf("failed to decode Telegram API response for method %q: %w", "getWebhookInfo", err)
+	}
+	return info, nil
+}
+
+// ReconcileWebhook sets the webhook described by config unless the live
+// webhook already matches it, so it is safe to call on every start of the
+// bot. It reports whether setWebhook was called.
+//
+// The URL is always compared. MaxConnections, AllowedUpdates and IPAddress
+// are compared when set, and a Certificate requires a custom certificate.
+// Telegram does not report the secret token, so a config with a SecretToken
+// is always set: a rotated token then reaches Telegram before the
+// WebhookHandler checking it starts rejecting updates.
+func (bot *BotAPI) ReconcileWebhook(config WebhookConfig) (bool, error) {
+	return bot.ReconcileWebhookContext(context.Background(), config)
+}
+
+// ReconcileWebhookContext is ReconcileWebhook using ctx for the HTTP calls.
+func (bot *BotAPI) ReconcileWebhookContext(ctx context.Context, config WebhookConfig) (bool, error) {
+	if err := config.Validate(); err != nil {
+		return false, err
+	}
+	info, err := bot.GetWebhookInfoContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	if config.matches(info) {
+		return false, nil
+	}
+	if bot.c != nil {
+		logus.Debugf(bot.c, "Telegram webhook differs from the desired one, calling setWebhook: pending_update_count=%d", info.PendingUpdateCount)
+	}
+	if _, err = bot.SetWebhookContext(ctx, config); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// matches reports whether info describes the webhook set by j.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (j WebhookConfig) matches(info WebhookInfo) bool {
+	if j.URL == nil || info.URL != j.URL.String() {
+		return false
+	}
+	if j.MaxConnections > 0 {
+		maxConnections := info.MaxConnections
+		if maxConnections == 0 {
+			maxConnections = DefaultWebhookMaxConnections
+		}
+		if maxConnections != j.MaxConnections {
+			return false
+		}
+	}
+	if len(j.AllowedUpdates) > 0 {
+		want, got := slices.Clone(j.AllowedUpdates), slices.Clone(info.AllowedUpdates)
+		slices.Sort(want)
+		slices.Sort(got)
+		if !slices.Equal(slices.Compact(want), slices.Compact(got)) {
+			return false
+		}
+	}
+	if j.IPAddress != "" && info.IPAddress != j.IPAddress {
+		return false
+	}
+	if j.Certificate != nil && !info.HasCustomCertificate {
+		return false
+	}
+	// The live secret token is unknown, it may differ from j.SecretToken.
+	return j.SecretToken == ""
+}
+
+// multipart returns the setWebhook request, uploading the certificate if there is one.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (j WebhookConfig) multipart() (*multipartRequest, error) {
+	values, err := j.Values()
+	if err != nil {
+		return nil, err
+	}
+	r := newMultipartRequest(paramsFromValues(values))
+	if j.Certificate != nil {
+		certificate, err := inputFileFrom(j.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("certificate: %w", err)
+		}
+		if err = r.addFile("certificate", certificate); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,140 @@
+package tgbotapi
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// webhookBot answers getWebhookInfo with info and records the other calls.
+func webhookBot(t *testing.T, info string, calls *[]*http.Request) *BotAPI {
+	t.Helper()
+	return NewBotAPIWithClient("123456:TOKEN", &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			body := `{"ok":true,"result":true}`
+			if strings.HasSuffix(r.URL.Path, "/getWebhookInfo") {
+				body = `{"ok":true,"result":` + info + `}`
+			} else {
+				if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+					if err := r.ParseMultipartForm(1 << 20); err != nil {
+						t.Fatal(err)
+					}
+				} else if err := r.ParseForm(); err != nil {
+					t.Fatal(err)
+				}
+				*calls = append(*calls, r)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	})
+}
+
+func TestGetWebhookInfo(t *testing.T) {
+	bot := webhookBot(t, `{"url":"https://example.com/hook","has_custom_certificate":false,"pending_update_count":3,
+		"last_error_date":1700000000,"last_error_message":"Connection refused","max_connections":40,"allowed_updates":["message"]}`, nil)
+	info, err := bot.GetWebhookInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.IsSet() || info.PendingUpdateCount != 3 || info.LastErrorDate != 1700000000 ||
+		info.LastErrorMessage != "Connection refused" || info.MaxConnections != 40 || len(info.AllowedUpdates) != 1 {
+		t.Errorf("info = %+v", info)
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	var calls []*http.Request
+	bot := webhookBot(t, `{}`, &calls)
+	if _, err := bot.DeleteWebhook(DeleteWebhookConfig{DropPendingUpdates: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.RemoveWebhook(); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("calls = %d, want 2", len(calls))
+	}
+	for i, wantDrop := range []string{"true", ""} {
+		if !strings.HasSuffix(calls[i].URL.Path, "/deleteWebhook") || calls[i].PostForm.Get("drop_pending_updates") != wantDrop {
+			t.Errorf("call %d: %s %v", i, calls[i].URL.Path, calls[i].PostForm)
+		}
+	}
+}
+
+func TestSetWebhook_CertificateKeepsAllParameters(t *testing.T) {
+	var calls []*http.Request
+	bot := webhookBot(t, `{}`, &calls)
+	config := NewWebhookWithCert("https://example.com/hook", FileBytes{Name: "cert.pem", Bytes: []byte("PEM")})
+	config.IPAddress = "203.0.113.7"
+	config.MaxConnections = 10
+	config.AllowedUpdates = []string{"message", "callback_query"}
+	config.DropPendingUpdates = true
+	config.SecretToken = "secret"
+	if _, err := bot.SetWebhook(*config); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("calls = %d, want 1", len(calls))
+	}
+	r := calls[0]
+	if got := formFile(t, r, "certificate"); got != "PEM" {
+		t.Errorf("certificate = %q", got)
+	}
+	want := url.Values{
+		"url":                  {"https://example.com/hook"},
+		"ip_address":           {"203.0.113.7"},
+		"max_connections":      {"10"},
+		"allowed_updates":      {`["message","callback_query"]`},
+		"drop_pending_updates": {"True"},
+		"secret_token":         {"secret"},
+	}
+	for key := range want {
+		if got := r.FormValue(key); got != want.Get(key) {
+			t.Errorf("%s = %q, want %q", key, got, want.Get(key))
+		}
+	}
+}
+
+func TestReconcileWebhook(t *testing.T) {
+	const live = `{"url":"https://example.com/hook","pending_update_count":0,"max_connections":40,"allowed_updates":["callback_query","message"]}`
+	for _, tt := range []struct {
+		name    string
+		config  func(*WebhookConfig)
+		changed bool
+	}{
+		{name: "same", config: func(*WebhookConfig) {}},
+		{name: "same_defaults", config: func(c *WebhookConfig) {
+			c.MaxConnections = DefaultWebhookMaxConnections
+			c.AllowedUpdates = []string{"message", "callback_query"}
+		}},
+		{name: "url", config: func(c *WebhookConfig) { c.URL, _ = url.Parse("https://example.com/other") }, changed: true},
+		{name: "max_connections", config: func(c *WebhookConfig) { c.MaxConnections = 100 }, changed: true},
+		{name: "allowed_updates", config: func(c *WebhookConfig) { c.AllowedUpdates = []string{"message"} }, changed: true},
+		{name: "ip_address", config: func(c *WebhookConfig) { c.IPAddress = "203.0.113.7" }, changed: true},
+		{name: "certificate", config: func(c *WebhookConfig) { c.Certificate = FileBytes{Name: "cert.pem", Bytes: []byte("PEM")} }, changed: true},
+		{name: "secret_token", config: func(c *WebhookConfig) { c.SecretToken = "rotated" }, changed: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []*http.Request
+			bot := webhookBot(t, live, &calls)
+			config := NewWebhook("https://example.com/hook")
+			tt.config(config)
+			changed, err := bot.ReconcileWebhook(*config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed != tt.changed || len(calls) != map[bool]int{false: 0, true: 1}[tt.changed] {
+				t.Errorf("changed = %t, setWebhook calls = %d, want %t", changed, len(calls), tt.changed)
+			}
+			if len(calls) == 1 && calls[0].FormValue("secret_token") != config.SecretToken {
+				t.Errorf("secret_token = %q, want %q", calls[0].FormValue("secret_token"), config.SecretToken)
+			}
+		})
+	}
+}
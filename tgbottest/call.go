@@ -0,0 +1,159 @@
+package tgbottest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Call is a Bot API request received by Server.
+type Call struct {
+	Method string
+	Params url.Values
+
+	// Files holds the files uploaded in a multipart request by field name.
+	Files map[string]File
+
+	// Webhook is true for a method called in a reply to a webhook update,
+	// see Server.PostWebhook.
+	Webhook bool
+}
+
+// File is a file uploaded in a multipart request.
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Decode fills the fields of the struct v points to, e.g. a tgbotapi config,
+// from the call parameters. Fields are matched by their JSON names, or
+// case-insensitively by Go name if they have none, including the fields of
+// embedded structs. Strings, numbers and booleans are parsed from their form
+// representation, other kinds from JSON. Fields of interface types other than
+// any, like tgbotapi.Photo, are left unset.
+func (c Call) Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("tgbottest: Decode requires a non-nil pointer to a struct")
+	}
+	return decodeStruct(rv.Elem(), c.Params)
+}
+
+func decodeStruct(rv reflect.Value, params url.Values) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := decodeStruct(rv.Field(i), params); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok := lookupParam(params, name, field.Name)
+		if !ok {
+			continue
+		}
+		if err := decodeValue(rv.Field(i), value); err != nil {
+			return fmt.Errorf("tgbottest: field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+func lookupParam(params url.Values, jsonName, goName string) (string, bool) {
+	if jsonName != "" {
+		if values, ok := params[jsonName]; ok && len(values) > 0 {
+			return values[0], true
+		}
+		return "", false
+	}
+	for key, values := range params {
+		if strings.EqualFold(key, goName) && len(values) > 0 {
+			return values[0], true
+		}
+	}
+	return "", false
+}
+
+func decodeValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Interface:
+		if v.NumMethod() > 0 {
+			return nil
+		}
+		var decoded any
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			decoded = s
+		}
+		if decoded != nil {
+			v.Set(reflect.ValueOf(decoded))
+		}
+	default:
+		return json.Unmarshal([]byte(s), v.Addr().Interface())
+	}
+	return nil
+}
+
+// readFiles reads the uploaded files of a multipart form.
+func readFiles(form *multipart.Form) (map[string]File, error) {
+	if len(form.File) == 0 {
+		return nil, nil
+	}
+	files := make(map[string]File, len(form.File))
+	for field, headers := range form.File {
+		if len(headers) == 0 {
+			continue
+		}
+		f, err := headers[0].Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[field] = File{Name: headers[0].Filename, Data: data}
+	}
+	return files, nil
+}
@@ -0,0 +1,223 @@
+package tgbottest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bots-go-framework/bots-api-telegram/tgbotapi"
+)
+
+// Response is a Bot API response scripted with Server.Enqueue or returned by
+// a Server.Handle handler.
+type Response struct {
+	// Result is encoded as JSON for a successful response.
+	Result any
+
+	// ErrorCode, if not zero, makes the response unsuccessful.
+	ErrorCode   int
+	Description string
+	Parameters  *tgbotapi.ResponseParameters
+}
+
+// OK returns a successful response with result.
+func OK(result any) Response {
+	return Response{Result: result}
+}
+
+// Error returns an unsuccessful response.
+func Error(errorCode int, description string) Response {
+	return Response{ErrorCode: errorCode, Description: description}
+}
+
+// TooManyRequests returns a flood-control error asking to retry after
+// retryAfter seconds.
+func TooManyRequests(retryAfter int) Response {
+	return Response{
+		ErrorCode:   http.StatusTooManyRequests,
+		Description: "Too Many Requests: retry after " + strconv.Itoa(retryAfter),
+		Parameters:  &tgbotapi.ResponseParameters{RetryAfter: retryAfter},
+	}
+}
+
+// BotBlocked returns the error of a user having blocked the bot.
+func BotBlocked() Response {
+	return Error(http.StatusForbidden, "Forbidden: bot was blocked by the user")
+}
+
+// ChatMigrated returns the error of a group upgraded to the supergroup newChatID.
+func ChatMigrated(newChatID int64) Response {
+	return Response{
+		ErrorCode:   http.StatusBadRequest,
+		Description: "Bad Request: group chat was upgraded to a supergroup chat",
+		Parameters:  &tgbotapi.ResponseParameters{MigrateToChatID: newChatID},
+	}
+}
+
+func writeResponse(w http.ResponseWriter, r Response) {
+	body := struct {
+		Ok          bool                         `json:"ok"`
+		Result      any                          `json:"result,omitempty"`
+		ErrorCode   int                          `json:"error_code,omitempty"`
+		Description string                       `json:"description,omitempty"`
+		Parameters  *tgbotapi.ResponseParameters `json:"parameters,omitempty"`
+	}{
+		Ok:          r.ErrorCode == 0,
+		Result:      r.Result,
+		ErrorCode:   r.ErrorCode,
+		Description: r.Description,
+		Parameters:  r.Parameters,
+	}
+	if body.Ok && body.Result == nil {
+		body.Result = true
+	}
+	status := http.StatusOK
+	if r.ErrorCode != 0 {
+		status = r.ErrorCode
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// defaultResponse answers calls without a scripted response or handler:
+// methods sending or editing messages get a message built from the call,
+// getMe gets BotUser, getFile a file_path, getChatMember a member, invite
+// link methods a link, and other methods true.
+func (s *Server) defaultResponse(call Call) Response {
+	switch call.Method {
+	case "getMe":
+		return OK(s.BotUser)
+	case "getFile":
+		fileID := call.Params.Get("file_id")
+		return OK(tgbotapi.File{FileID: fileID, FilePath: "files/" + fileID})
+	case "getWebhookInfo":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		info := s.webhook
+		info.PendingUpdateCount = len(s.updates)
+		return OK(info)
+	case "setWebhook":
+		var info tgbotapi.WebhookInfo
+		if err := call.Decode(&info); err != nil {
+			return Error(http.StatusBadRequest, "Bad Request: "+err.Error())
+		}
+		_, info.HasCustomCertificate = call.Files["certificate"]
+		s.mu.Lock()
+		s.webhook, s.secretToken = info, call.Params.Get("secret_token")
+		if d, _ := strconv.ParseBool(call.Params.Get("drop_pending_updates")); d {
+			s.updates = nil
+		}
+		s.mu.Unlock()
+		return OK(true)
+	case "deleteWebhook", "removeWebhook":
+		s.mu.Lock()
+		s.webhook, s.secretToken = tgbotapi.WebhookInfo{}, ""
+		if d, _ := strconv.ParseBool(call.Params.Get("drop_pending_updates")); d {
+			s.updates = nil
+		}
+		s.mu.Unlock()
+		return OK(true)
+	case "getChatMember":
+		userID, _ := strconv.ParseInt(call.Params.Get("user_id"), 10, 64)
+		return OK(map[string]any{"status": "member", "user": tgbotapi.User{ID: userID, FirstName: "User" + strconv.FormatInt(userID, 10)}})
+	case "getChatMemberCount":
+		return OK(1)
+	case "createChatInviteLink", "editChatInviteLink", "createChatSubscriptionInviteLink", "revokeChatInviteLink":
+		return OK(s.inviteLink(call))
+	case "getStarTransactions":
+		return OK(tgbotapi.StarTransactions{Transactions: []tgbotapi.StarTransaction{}})
+	case "getMyStarBalance":
+		return OK(tgbotapi.StarAmount{})
+	case "editUserStarSubscription":
+		return OK(true)
+	case "getAvailableGifts":
+		return OK(tgbotapi.Gifts{Gifts: []tgbotapi.Gift{}})
+	case "getUserGifts", "getChatGifts", "getBusinessAccountGifts":
+		return OK(tgbotapi.OwnedGifts{Gifts: []tgbotapi.OwnedGift{}})
+	case "sendChatAction":
+		return OK(true)
+	case "sendMediaGroup":
+		var media []json.RawMessage
+		_ = json.Unmarshal([]byte(call.Params.Get("media")), &media)
+		messages := make([]tgbotapi.Message, len(media))
+		for i := range messages {
+			messages[i] = s.newMessage(call)
+		}
+		return OK(messages)
+	case "copyMessage":
+		return OK(tgbotapi.MessageID{MessageID: s.newMessage(call).MessageID})
+	case "copyMessages", "forwardMessages":
+		var ids []int
+		_ = json.Unmarshal([]byte(call.Params.Get("message_ids")), &ids)
+		messageIDs := make([]tgbotapi.MessageID, len(ids))
+		for i := range messageIDs {
+			messageIDs[i].MessageID = s.newMessage(call).MessageID
+		}
+		return OK(messageIDs)
+	}
+	if strings.HasPrefix(call.Method, "send") || strings.HasPrefix(call.Method, "forward") || strings.HasPrefix(call.Method, "edit") {
+		return OK(s.newMessage(call))
+	}
+	return OK(true)
+}
+
+// newMessage returns the message sent by a call.
+func (s *Server) newMessage(call Call) tgbotapi.Message {
+	s.mu.Lock()
+	s.nextMessageID++
+	messageID := s.nextMessageID
+	s.mu.Unlock()
+
+	chat := &tgbotapi.Chat{Type: "private"}
+	if chatID := call.Params.Get("chat_id"); strings.HasPrefix(chatID, "@") {
+		chat.Type, chat.UserName = "channel", strings.TrimPrefix(chatID, "@")
+	} else {
+		chat.ID, _ = strconv.ParseInt(chatID, 10, 64)
+		if chat.ID < 0 {
+			chat.Type = "supergroup"
+		}
+	}
+	if id, err := strconv.Atoi(call.Params.Get("message_id")); err == nil && strings.HasPrefix(call.Method, "edit") {
+		messageID = id
+	}
+
+	from := s.BotUser
+	return tgbotapi.Message{
+		MessageID: messageID,
+		From:      &from,
+		Date:      int(time.Now().Unix()),
+		Chat:      chat,
+		Text:      call.Params.Get("text"),
+		Caption:   call.Params.Get("caption"),
+	}
+}
+
+// inviteLink returns the invite link created, edited or revoked by a call.
+func (s *Server) inviteLink(call Call) tgbotapi.ChatInviteLink {
+	link := call.Params.Get("invite_link")
+	if link == "" {
+		s.mu.Lock()
+		s.nextMessageID++
+		link = "https://t.me/+test" + strconv.Itoa(s.nextMessageID)
+		s.mu.Unlock()
+	}
+	expireDate, _ := strconv.Atoi(call.Params.Get("expire_date"))
+	memberLimit, _ := strconv.Atoi(call.Params.Get("member_limit"))
+	createsJoinRequest, _ := strconv.ParseBool(call.Params.Get("creates_join_request"))
+	subscriptionPeriod, _ := strconv.Atoi(call.Params.Get("subscription_period"))
+	subscriptionPrice, _ := strconv.Atoi(call.Params.Get("subscription_price"))
+	return tgbotapi.ChatInviteLink{
+		InviteLink:         link,
+		Creator:            s.BotUser,
+		CreatesJoinRequest: createsJoinRequest,
+		IsRevoked:          call.Method == "revokeChatInviteLink",
+		Name:               call.Params.Get("name"),
+		ExpireDate:         expireDate,
+		MemberLimit:        memberLimit,
+		SubscriptionPeriod: subscriptionPeriod,
+		SubscriptionPrice:  subscriptionPrice,
+	}
+}
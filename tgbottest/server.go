@@ -0,0 +1,234 @@
+// Package tgbottest provides an in-process fake Telegram Bot API server for
+// testing bots built with tgbotapi without network access or a real token.
+//
+//	srv := tgbottest.NewServer(t)
+//	bot := srv.Bot()
+//	srv.Enqueue("sendMessage", tgbottest.BotBlocked())
+//	_, err := bot.Send(tgbotapi.NewMessage(42, "hi")) // errors.Is(err, tgbotapi.ErrBotBlocked)
+//	call, _ := srv.LastCall("sendMessage")
+package tgbottest
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bots-go-framework/bots-api-telegram/tgbotapi"
+)
+
+// Token is the bot token accepted by Server.
+const Token = "123456789:TEST-TOKEN"
+
+// maxMemory is the size of multipart uploads kept in memory by ParseMultipartForm.
+const maxMemory = 32 << 20
+
+// Server is a fake Bot API server. It records every call, answers with
+// scripted or default responses and serves injected updates.
+type Server struct {
+	// BotUser is returned by getMe and used as the sender of sent messages.
+	BotUser tgbotapi.User
+
+	httpServer *httptest.Server
+
+	mu            sync.Mutex
+	calls         []Call
+	queued        map[string][]Response
+	handlers      map[string]func(Call) Response
+	updates       []tgbotapi.Update
+	nextUpdateID  int
+	nextMessageID int
+	newUpdates    chan struct{}        // closed and replaced when an update is added
+	webhook       tgbotapi.WebhookInfo // set with setWebhook, PendingUpdateCount is not maintained
+	secretToken   string
+	closed        chan struct{}
+	closeOnce     sync.Once
+}
+
+// NewServer starts a fake Bot API server that is closed when the test ends.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+	s := &Server{
+		BotUser:      tgbotapi.User{ID: 123456789, IsBot: true, FirstName: "Test", UserName: "test_bot"},
+		queued:       make(map[string][]Response),
+		handlers:     make(map[string]func(Call) Response),
+		nextUpdateID: 1,
+		newUpdates:   make(chan struct{}),
+		closed:       make(chan struct{}),
+	}
+	s.httpServer = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// URL returns the base URL of the server, to be used as BotAPI.BaseURL.
+func (s *Server) URL() string {
+	return s.httpServer.URL
+}
+
+// Bot returns a BotAPI talking to the server.
+func (s *Server) Bot() *tgbotapi.BotAPI {
+	bot := tgbotapi.NewBotAPIWithClient(Token, s.httpServer.Client())
+	bot.BaseURL = s.httpServer.URL
+	bot.Self = s.BotUser
+	return bot
+}
+
+// Close stops the server, releasing pending getUpdates long polls.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		s.httpServer.Close()
+	})
+}
+
+// Calls returns all recorded calls in the order they were received.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// CallsTo returns the recorded calls of method.
+func (s *Server) CallsTo(method string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var calls []Call
+	for _, call := range s.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// LastCall returns the most recent call of method.
+func (s *Server) LastCall(method string) (Call, bool) {
+	calls := s.CallsTo(method)
+	if len(calls) == 0 {
+		return Call{}, false
+	}
+	return calls[len(calls)-1], true
+}
+
+// Enqueue scripts the responses to the next calls of method, one response
+// per call. Once they are used up, calls get the handler or default response.
+func (s *Server) Enqueue(method string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queued[method] = append(s.queued[method], responses...)
+}
+
+// Handle sets the handler answering calls of method that have no queued
+// response. It replaces the default response.
+func (s *Server) Handle(method string, handler func(Call) Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	method, ok := s.methodFromPath(r.URL.Path)
+	if !ok {
+		writeResponse(w, Error(http.StatusNotFound, "Not Found"))
+		return
+	}
+	call, err := readCall(method, r)
+	if err != nil {
+		writeResponse(w, Error(http.StatusBadRequest, "Bad Request: "+err.Error()))
+		return
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, call)
+	response, scripted := s.dequeue(method)
+	handler := s.handlers[method]
+	s.mu.Unlock()
+
+	switch {
+	case scripted:
+	case handler != nil:
+		response = handler(call)
+	case method == "getUpdates":
+		response = s.getUpdates(r, call)
+	default:
+		response = s.defaultResponse(call)
+	}
+	writeResponse(w, response)
+}
+
+// methodFromPath returns the Bot API method of a /bot<token>/<method> or
+// /bot<token>/test/<method> path.
+func (s *Server) methodFromPath(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, "/bot"+Token+"/")
+	if !ok {
+		return "", false
+	}
+	rest = strings.TrimPrefix(rest, "test/")
+	return rest, rest != "" && !strings.Contains(rest, "/")
+}
+
+// dequeue returns the next scripted response to method. Requires s.mu.
+func (s *Server) dequeue(method string) (Response, bool) {
+	queue := s.queued[method]
+	if len(queue) == 0 {
+		return Response{}, false
+	}
+	s.queued[method] = queue[1:]
+	return queue[0], true
+}
+
+// readCall decodes the form or multipart parameters of a request.
+func readCall(method string, r *http.Request) (Call, error) {
+	call := Call{Method: method, Params: url.Values{}}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(maxMemory); err != nil {
+			return call, err
+		}
+		call.Params = r.MultipartForm.Value
+		files, err := readFiles(r.MultipartForm)
+		if err != nil {
+			return call, err
+		}
+		call.Files = files
+	case "application/json":
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return call, err
+		}
+		if call.Params, err = jsonParams(data); err != nil {
+			return call, err
+		}
+	default:
+		if err := r.ParseForm(); err != nil {
+			return call, err
+		}
+		call.Params = r.PostForm
+	}
+	return call, nil
+}
+
+// jsonParams converts a JSON object to parameters: strings are kept as is,
+// other values as JSON, the way they are sent in a form.
+func jsonParams(data []byte) (url.Values, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	params := make(url.Values, len(fields))
+	for key, value := range fields {
+		var s string
+		if json.Unmarshal(value, &s) != nil {
+			s = string(value)
+		}
+		params.Set(key, s)
+	}
+	return params, nil
+}
@@ -0,0 +1,154 @@
+package tgbottest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bots-go-framework/bots-api-telegram/tgbotapi"
+	"github.com/bots-go-framework/bots-api-telegram/tgbottest"
+)
+
+func TestServer_RecordsAndDecodesCalls(t *testing.T) {
+	srv := tgbottest.NewServer(t)
+	bot := srv.Bot()
+
+	config := tgbotapi.NewMessage(42, "hello")
+	config.ParseMode = "HTML"
+	config.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("Yes", "yes"),
+	))
+	message, err := bot.Send(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message.MessageID == 0 || message.Chat.ID != 42 || message.Text != "hello" {
+		t.Errorf("message = %+v", message)
+	}
+
+	call, ok := srv.LastCall("sendMessage")
+	if !ok {
+		t.Fatal("sendMessage was not called")
+	}
+	var got tgbotapi.MessageConfig
+	if err = call.Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ChatID != 42 || got.Text != "hello" || got.ParseMode != "HTML" || got.ReplyMarkup == nil {
+		t.Errorf("decoded %+v", got)
+	}
+}
+
+func TestServer_Uploads(t *testing.T) {
+	srv := tgbottest.NewServer(t)
+	photo := tgbotapi.NewPhotoUpload(1, tgbotapi.NewInputFileBytes("cat.jpg", []byte("meow")))
+	if _, err := srv.Bot().Send(photo); err != nil {
+		t.Fatal(err)
+	}
+	call, _ := srv.LastCall("sendPhoto")
+	if file := call.Files["photo"]; file.Name != "cat.jpg" || string(file.Data) != "meow" {
+		t.Errorf("photo = %+v", file)
+	}
+}
+
+func TestServer_ScriptedErrors(t *testing.T) {
+	srv := tgbottest.NewServer(t)
+	bot := srv.Bot()
+	bot.RetryPolicy.FollowChatMigration = true
+
+	srv.Enqueue("sendMessage", tgbottest.BotBlocked(), tgbottest.TooManyRequests(30), tgbottest.ChatMigrated(-100123))
+
+	if _, err := bot.Send(tgbotapi.NewMessage(1, "a")); !errors.Is(err, tgbotapi.ErrBotBlocked) {
+		t.Errorf("err = %v, want ErrBotBlocked", err)
+	}
+	_, err := bot.Send(tgbotapi.NewMessage(1, "b"))
+	details, _ := tgbotapi.TelegramProviderErrorDetailsFrom(err)
+	if !errors.Is(err, tgbotapi.ErrTooManyRequests) || details.RetryAfter != 30*time.Second {
+		t.Errorf("err = %v, details = %+v, want retry after 30", err, details)
+	}
+	message, err := bot.Send(tgbotapi.NewMessage(-1, "c"))
+	if err != nil || message.Chat.ID != -100123 {
+		t.Errorf("message = %+v, err = %v, want the migrated chat", message, err)
+	}
+	if calls := srv.CallsTo("sendMessage"); len(calls) != 4 {
+		t.Errorf("sendMessage calls = %d, want 4", len(calls))
+	}
+}
+
+func TestServer_GetUpdates(t *testing.T) {
+	srv := tgbottest.NewServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := tgbotapi.NewUpdater(srv.Bot(), tgbotapi.UpdateConfig{Timeout: 30}).Start(ctx)
+
+	srv.AddMessage(1, 2, "/start")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		srv.AddMessage(1, 2, "second") // delivered to a pending long poll
+	}()
+
+	for _, want := range []string{"/start", "second"} {
+		select {
+		case update := <-updates:
+			if update.Message == nil || update.Message.Text != want {
+				t.Fatalf("update = %+v, want %q", update, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+}
+
+func TestServer_Webhook(t *testing.T) {
+	srv := tgbottest.NewServer(t)
+	bot := srv.Bot()
+	handler := tgbotapi.NewWebhookHandler("secret", func(ctx context.Context, update tgbotapi.Update) (tgbotapi.Sendable, error) {
+		return tgbotapi.NewMessage(update.Message.Chat.ID, "echo: "+update.Message.Text), nil
+	})
+	webhookServer := httptest.NewServer(handler)
+	defer webhookServer.Close()
+
+	webhook := tgbotapi.NewWebhook(webhookServer.URL)
+	webhook.SecretToken = "secret"
+	if _, err := bot.SetWebhook(*webhook); err != nil {
+		t.Fatal(err)
+	}
+
+	update := tgbotapi.Update{UpdateID: 1, Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 7}, Text: "hi"}}
+	if status, err := srv.PostWebhook(update); err != nil || status != http.StatusOK {
+		t.Fatalf("status = %d, err = %v", status, err)
+	}
+	if status, _ := srv.ServeWebhook(handler, "wrong", update); status != http.StatusUnauthorized {
+		t.Errorf("wrong secret: status = %d", status)
+	}
+
+	call, ok := srv.LastCall("sendMessage")
+	if !ok || !call.Webhook || call.Params.Get("text") != "echo: hi" || call.Params.Get("chat_id") != "7" {
+		t.Fatalf("webhook reply = %+v", call)
+	}
+}
+
+func TestServer_ReconcileWebhook(t *testing.T) {
+	srv := tgbottest.NewServer(t)
+	bot := srv.Bot()
+	webhook := tgbotapi.NewWebhook("https://example.com/hook")
+	webhook.MaxConnections = 10
+	webhook.AllowedUpdates = []string{"message"}
+
+	for i, want := range []bool{true, false} {
+		changed, err := bot.ReconcileWebhook(*webhook)
+		if err != nil || changed != want {
+			t.Fatalf("reconcile %d: changed = %t, err = %v, want %t", i, changed, err, want)
+		}
+	}
+	info, err := bot.GetWebhookInfo()
+	if err != nil || info.URL != "https://example.com/hook" || info.MaxConnections != 10 {
+		t.Errorf("info = %+v, err = %v", info, err)
+	}
+	if calls := srv.CallsTo("setWebhook"); len(calls) != 1 {
+		t.Errorf("setWebhook calls = %d, want 1", len(calls))
+	}
+}
@@ -0,0 +1,186 @@
+package tgbottest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bots-go-framework/bots-api-telegram/tgbotapi"
+)
+
+// AddUpdate queues updates for getUpdates. An UpdateID of 0 is replaced with
+// the next sequential ID. It returns the queued updates.
+func (s *Server) AddUpdate(updates ...tgbotapi.Update) []tgbotapi.Update {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range updates {
+		if updates[i].UpdateID == 0 {
+			updates[i].UpdateID = s.nextUpdateID
+		}
+		if updates[i].UpdateID >= s.nextUpdateID {
+			s.nextUpdateID = updates[i].UpdateID + 1
+		}
+		s.updates = append(s.updates, updates[i])
+	}
+	close(s.newUpdates)
+	s.newUpdates = make(chan struct{})
+	return updates
+}
+
+// AddMessage queues a message update for getUpdates from user in chat with text.
+func (s *Server) AddMessage(chatID, userID int64, text string) tgbotapi.Update {
+	s.mu.Lock()
+	s.nextMessageID++
+	message := &tgbotapi.Message{
+		MessageID: s.nextMessageID,
+		From:      &tgbotapi.User{ID: userID, FirstName: "User" + strconv.FormatInt(userID, 10)},
+		Date:      int(time.Now().Unix()),
+		Chat:      &tgbotapi.Chat{ID: chatID, Type: "private"},
+		Text:      text,
+	}
+	s.mu.Unlock()
+	if chatID < 0 {
+		message.Chat.Type = "supergroup"
+	}
+	return s.AddUpdate(tgbotapi.Update{Message: message})[0]
+}
+
+// getUpdates confirms updates before offset and returns the pending ones,
+// waiting up to timeout seconds for new updates like the real server.
+func (s *Server) getUpdates(r *http.Request, call Call) Response {
+	offset, _ := strconv.Atoi(call.Params.Get("offset"))
+	limit, _ := strconv.Atoi(call.Params.Get("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	timeout, _ := strconv.Atoi(call.Params.Get("timeout"))
+	deadline := time.NewTimer(time.Duration(timeout) * time.Second)
+	defer deadline.Stop()
+
+	for {
+		s.mu.Lock()
+		if offset > 0 {
+			i := 0
+			for i < len(s.updates) && s.updates[i].UpdateID < offset {
+				i++
+			}
+			s.updates = s.updates[i:]
+		}
+		pending := s.updates[:min(limit, len(s.updates))]
+		newUpdates := s.newUpdates
+		s.mu.Unlock()
+
+		if len(pending) > 0 || timeout <= 0 {
+			return OK(append([]tgbotapi.Update{}, pending...))
+		}
+		select {
+		case <-newUpdates:
+		case <-deadline.C:
+			return OK([]tgbotapi.Update{})
+		case <-r.Context().Done():
+			return OK([]tgbotapi.Update{})
+		case <-s.closed:
+			return OK([]tgbotapi.Update{})
+		}
+	}
+}
+
+// PostWebhook delivers update to the URL set with setWebhook, with the secret
+// token header if one was set, like Telegram does. A method called in the
+// webhook reply is recorded as a Call with Webhook set.
+//
+// It returns the HTTP status of the webhook response.
+func (s *Server) PostWebhook(update tgbotapi.Update) (int, error) {
+	s.mu.Lock()
+	webhookURL, secretToken := s.webhook.URL, s.secretToken
+	s.mu.Unlock()
+	if webhookURL == "" {
+		return 0, errors.New("tgbottest: no webhook is set")
+	}
+	return s.postWebhook(http.DefaultClient, webhookURL, secretToken, update)
+}
+
+// ServeWebhook delivers update to handler in-process, e.g. to a
+// tgbotapi.WebhookHandler, with secretToken in the secret token header.
+// A method called in the reply is recorded as a Call with Webhook set.
+//
+// It returns the HTTP status of the webhook response.
+func (s *Server) ServeWebhook(handler http.Handler, secretToken string, update tgbotapi.Update) (int, error) {
+	client := &http.Client{Transport: handlerTransport{handler}}
+	return s.postWebhook(client, "http://webhook.test/", secretToken, update)
+}
+
+func (s *Server) postWebhook(client *http.Client, webhookURL, secretToken string, update tgbotapi.Update) (int, error) {
+	body, err := json.Marshal(update)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secretToken != "" {
+		req.Header.Set(tgbotapi.SecretTokenHeader, secretToken)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	reply, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res.StatusCode, err
+	}
+	if res.StatusCode == http.StatusOK && len(reply) > 0 {
+		if err = s.recordWebhookReply(res.Header.Get("Content-Type"), reply); err != nil {
+			return res.StatusCode, err
+		}
+	}
+	return res.StatusCode, nil
+}
+
+// recordWebhookReply records the method called in a webhook response.
+func (s *Server) recordWebhookReply(contentType string, reply []byte) error {
+	call := Call{Webhook: true}
+	var err error
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "application/json" {
+		call.Params, err = jsonParams(reply)
+	} else {
+		call.Params, err = url.ParseQuery(string(reply))
+	}
+	if err != nil {
+		return fmt.Errorf("tgbottest: invalid webhook reply: %w", err)
+	}
+	call.Method = call.Params.Get("method")
+	if call.Method == "" {
+		return errors.New("tgbottest: webhook reply has no method")
+	}
+	call.Params.Del("method")
+
+	s.mu.Lock()
+	s.calls = append(s.calls, call)
+	s.mu.Unlock()
+	return nil
+}
+
+// handlerTransport serves requests with an http.Handler in-process.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	t.handler.ServeHTTP(w, r)
+	return w.Result(), nil
+}
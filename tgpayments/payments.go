@@ -0,0 +1,442 @@
+// Package tgpayments tracks Telegram payments from the invoice to the
+// pre-checkout query, the successful payment and the refund, correlated by
+// the invoice payload.
+//
+//	payments := tgpayments.NewManager(bot, store)
+//	payments.Validate = func(ctx context.Context, payment tgpayments.Payment, query *tgbotapi.PreCheckoutQuery) error {
+//		if !inStock(ctx, payment.Payload) {
+//			return tgpayments.Reject("Sorry, the item is sold out.")
+//		}
+//		return nil
+//	}
+//	payments.OnEvent = func(ctx context.Context, event tgpayments.Event) error { ... }
+//	payments.Register(dispatcher)
+//	_, err := payments.SendInvoice(ctx, invoice)
+package tgpayments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bots-go-framework/bots-api-telegram/tgbotapi"
+)
+
+// DefaultPreCheckoutTimeout is the default of Manager.PreCheckoutTimeout.
+// Telegram cancels the checkout if a pre-checkout query is not answered
+// within 10 seconds of being sent.
+const DefaultPreCheckoutTimeout = 8 * time.Second
+
+// DefaultErrorMessage is the default of Manager.ErrorMessage.
+const DefaultErrorMessage = "Sorry, the payment can't be processed right now. Please try again later."
+
+// Messages shown to the user when Manager rejects a pre-checkout query itself.
+const (
+	UnknownInvoiceMessage = "This invoice is no longer available."
+	AlreadyPaidMessage    = "This invoice has already been paid."
+	ChangedInvoiceMessage = "The invoice has changed, please request a new one."
+)
+
+// ErrPayloadInUse is returned by Manager.SendInvoice for a payload of an
+// invoice that is already being paid, paid or refunded.
+var ErrPayloadInUse = errors.New("invoice payload is already in use")
+
+// State is the stage of a payment lifecycle.
+type State string
+
+// Payment states in lifecycle order.
+const (
+	StateInvoiced State = "invoiced" // the invoice was sent
+	StateRejected State = "rejected" // the last pre-checkout query was rejected
+	StateApproved State = "approved" // the last pre-checkout query was approved
+	StatePaid     State = "paid"     // a successful payment was received
+	StateRefunded State = "refunded" // the payment was refunded
+)
+
+// Payment is the state of a payment stored by a Store.
+type Payment struct {
+	// Bot-defined invoice payload identifying the payment
+	Payload string `json:"payload"`
+
+	// Current state of the payment
+	State State `json:"state"`
+
+	// Chat the invoice was sent to
+	ChatID int64 `json:"chat_id,omitempty"`
+
+	// User paying the invoice, known from the pre-checkout query
+	UserID int64 `json:"user_id,omitempty"`
+
+	// Three-letter ISO 4217 currency code, or "XTR" for payments in Telegram Stars
+	Currency string `json:"currency"`
+
+	// Sum of the invoice prices in the smallest units of the currency
+	TotalAmount int `json:"total_amount"`
+
+	// The maximum accepted amount for tips in the smallest units of the currency
+	MaxTipAmount int `json:"max_tip_amount,omitempty"`
+
+	// Total amount paid including tips, in the smallest units of the currency
+	PaidAmount int `json:"paid_amount,omitempty"`
+
+	// Identifier of the last answered pre-checkout query
+	PreCheckoutQueryID string `json:"pre_checkout_query_id,omitempty"`
+
+	// Error message of the last rejected pre-checkout query
+	RejectReason string `json:"reject_reason,omitempty"`
+
+	// Telegram payment identifiers of the successful payments, one per subscription period for recurring payments
+	TelegramPaymentChargeIDs []string `json:"telegram_payment_charge_ids,omitempty"`
+
+	// Provider payment identifier of the last successful payment
+	ProviderPaymentChargeID string `json:"provider_payment_charge_id,omitempty"`
+
+	// Expiration date of the subscription in Unix time, for recurring payments only
+	SubscriptionExpirationDate int64 `json:"subscription_expiration_date,omitempty"`
+
+	// Telegram payment identifiers of the refunded payments
+	RefundedChargeIDs []string `json:"refunded_charge_ids,omitempty"`
+}
+
+// TelegramPaymentChargeID returns the identifier of the last successful payment.
+func (p Payment) TelegramPaymentChargeID() string {
+	if len(p.TelegramPaymentChargeIDs) == 0 {
+		return ""
+	}
+	return p.TelegramPaymentChargeIDs[len(p.TelegramPaymentChargeIDs)-1]
+}
+
+// Event is emitted by Manager for every change of a payment state.
+//
+// An update delivered again, e.g. a webhook retried after a handler error,
+// emits the event again with the same ID and Duplicate set, so handlers
+// should be idempotent by ID.
+type Event struct {
+	// Unique identifier of the transition, e.g. "paid:<telegram_payment_charge_id>"
+	ID string
+
+	// State the transition moved the payment to
+	State State
+
+	// The payment after the transition
+	Payment Payment
+
+	// True if the transition was already recorded by an earlier delivery
+	Duplicate bool
+}
+
+// RejectError is returned by a Manager.Validate function to reject a
+// pre-checkout query with a message to the user.
+type RejectError struct {
+	Message string
+}
+
+func (e *RejectError) Error() string {
+	return "pre-checkout query rejected: " + e.Message
+}
+
+// Reject returns a RejectError with the message shown to the user.
+func Reject(message string) error {
+	return &RejectError{Message: message}
+}
+
+// Manager answers pre-checkout queries and records the payment lifecycle in
+// a Store.
+type Manager struct {
+	bot   *tgbotapi.BotAPI
+	store Store
+
+	// Validate is called for a pre-checkout query matching a sent invoice,
+	// e.g. to check that goods are available. Returning an error made with
+	// Reject declines the checkout with its message, and any other error
+	// with ErrorMessage. Optional.
+	Validate func(ctx context.Context, payment Payment, query *tgbotapi.PreCheckoutQuery) error
+
+	// OnEvent is called after each change of a payment state was saved.
+	// Its error is returned by the handler. Optional.
+	OnEvent func(ctx context.Context, event Event) error
+
+	// PreCheckoutTimeout limits the time Validate may take, so the query is
+	// answered before Telegram gives up on it. Validate is not waited for
+	// after the timeout and the checkout is declined with ErrorMessage.
+	PreCheckoutTimeout time.Duration
+
+	// ErrorMessage is shown to the user when a pre-checkout query fails
+	// for a reason other than a rejection.
+	ErrorMessage string
+}
+
+// NewManager creates a Manager answering with bot and saving payments to store.
+func NewManager(bot *tgbotapi.BotAPI, store Store) *Manager {
+	return &Manager{
+		bot:                bot,
+		store:              store,
+		PreCheckoutTimeout: DefaultPreCheckoutTimeout,
+		ErrorMessage:       DefaultErrorMessage,
+	}
+}
+
+// Register routes the payment updates handled by HandleUpdate to m. It
+// should be called before registering other message handlers, as the
+// dispatcher uses the first matching route.
+func (m *Manager) Register(d *tgbotapi.Dispatcher) {
+	d.Handle(IsPaymentUpdate, func(ctx context.Context, _ *tgbotapi.BotAPI, update tgbotapi.Update) error {
+		return m.HandleUpdate(ctx, update)
+	})
+}
+
+// IsPaymentUpdate reports whether the update is a pre-checkout query or a
+// message about a successful or refunded payment.
+func IsPaymentUpdate(update tgbotapi.Update) bool {
+	return update.PreCheckoutQuery != nil ||
+		update.Message != nil && (update.Message.SuccessfulPayment != nil || update.Message.RefundedPayment != nil)
+}
+
+// HandleUpdate handles an update accepted by IsPaymentUpdate and ignores others.
+func (m *Manager) HandleUpdate(ctx context.Context, update tgbotapi.Update) error {
+	switch {
+	case update.PreCheckoutQuery != nil:
+		return m.HandlePreCheckoutQuery(ctx, update.PreCheckoutQuery)
+	case update.Message != nil && update.Message.SuccessfulPayment != nil:
+		return m.HandleSuccessfulPayment(ctx, update.Message)
+	case update.Message != nil && update.Message.RefundedPayment != nil:
+		return m.HandleRefundedPayment(ctx, update.Message)
+	}
+	return nil
+}
+
+// SendInvoice validates and records the invoice as StateInvoiced, then sends
+// it. Sending an invoice again with the same payload is allowed until the
+// payment is approved at pre-checkout.
+func (m *Manager) SendInvoice(ctx context.Context, config *tgbotapi.InvoiceConfig) (tgbotapi.Message, error) {
+	if err := config.Validate(); err != nil {
+		return tgbotapi.Message{}, err
+	}
+	var totalAmount int
+	for _, price := range config.Prices {
+		totalAmount += price.Amount
+	}
+	event, err := m.transition(ctx, config.Payload, StateInvoiced, config.Payload, func(p *Payment) (bool, error) {
+		switch p.State {
+		case "", StateInvoiced, StateRejected:
+		default:
+			return false, fmt.Errorf("%w: payment %q is %s", ErrPayloadInUse, p.Payload, p.State)
+		}
+		duplicate := p.State == StateInvoiced
+		p.State, p.ChatID = StateInvoiced, config.ChatID
+		p.Currency, p.TotalAmount, p.MaxTipAmount = config.Currency, totalAmount, int(config.MaxTipAmount)
+		return duplicate, nil
+	})
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+	message, err := m.bot.SendContext(ctx, config)
+	if err != nil {
+		return message, err
+	}
+	return message, m.emit(ctx, event)
+}
+
+// HandlePreCheckoutQuery answers the query and records the payment as
+// StateApproved or StateRejected.
+//
+// A query is rejected if its payload is unknown, the payment is already paid,
+// the currency or amount differ from the invoice, or Validate fails.
+func (m *Manager) HandlePreCheckoutQuery(ctx context.Context, query *tgbotapi.PreCheckoutQuery) error {
+	payment, err := m.store.Get(ctx, query.InvoicePayload)
+	if errors.Is(err, ErrNotFound) {
+		return m.answer(ctx, query.ID, UnknownInvoiceMessage)
+	}
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to get payment %q: %w", query.InvoicePayload, err), m.answer(ctx, query.ID, m.ErrorMessage))
+	}
+	if payment.PreCheckoutQueryID == query.ID {
+		// Already answered, as the state is saved only after the answer.
+		state := StateApproved
+		if payment.RejectReason != "" {
+			state = StateRejected
+		}
+		return m.emit(ctx, Event{ID: eventID(state, query.ID), State: state, Payment: payment, Duplicate: true})
+	}
+
+	var validateErr error
+	message := checkInvoice(payment, query)
+	if message == "" {
+		if validateErr = m.validate(ctx, payment, query); validateErr != nil {
+			var reject *RejectError
+			if errors.As(validateErr, &reject) {
+				message, validateErr = reject.Message, nil
+			} else {
+				message = m.ErrorMessage
+			}
+		}
+	}
+	if err = m.answer(ctx, query.ID, message); err != nil {
+		return errors.Join(validateErr, err)
+	}
+
+	state := StateApproved
+	if message != "" {
+		state = StateRejected
+	}
+	event, err := m.transition(ctx, query.InvoicePayload, state, query.ID, func(p *Payment) (bool, error) {
+		if p.PreCheckoutQueryID == query.ID {
+			return true, nil
+		}
+		p.State, p.PreCheckoutQueryID, p.RejectReason = state, query.ID, message
+		if query.From != nil {
+			p.UserID = query.From.ID
+		}
+		return false, nil
+	})
+	if err != nil {
+		return errors.Join(validateErr, err)
+	}
+	return errors.Join(validateErr, m.emit(ctx, event))
+}
+
+// checkInvoice returns the message rejecting a query that doesn't match the payment.
+func checkInvoice(payment Payment, query *tgbotapi.PreCheckoutQuery) string {
+	switch {
+	case payment.State == StatePaid || payment.State == StateRefunded:
+		return AlreadyPaidMessage
+	case query.Currency != payment.Currency,
+		query.TotalAmount < payment.TotalAmount,
+		query.TotalAmount > payment.TotalAmount+payment.MaxTipAmount:
+		return ChangedInvoiceMessage
+	}
+	return ""
+}
+
+// validate calls m.Validate, giving up after m.PreCheckoutTimeout.
+func (m *Manager) validate(ctx context.Context, payment Payment, query *tgbotapi.PreCheckoutQuery) error {
+	if m.Validate == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.PreCheckoutTimeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic while validating payment %q: %v", payment.Payload, r)
+			}
+		}()
+		done <- m.Validate(ctx, payment, query)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("validation of payment %q was not completed in %v: %w", payment.Payload, m.PreCheckoutTimeout, ctx.Err())
+	}
+}
+
+func (m *Manager) answer(ctx context.Context, queryID, errorMessage string) error {
+	config := tgbotapi.AnswerPreCheckoutQueryWithOK(queryID)
+	if errorMessage != "" {
+		config = tgbotapi.AnswerPreCheckoutQueryWithNotOK(queryID, errorMessage)
+	}
+	_, err := m.bot.MakeRequestFromChattableContext(ctx, config)
+	return err
+}
+
+// HandleSuccessfulPayment records the payment of message.SuccessfulPayment
+// as StatePaid. Every period of a subscription is recorded as a separate
+// payment. A payment of an unknown invoice is recorded too.
+func (m *Manager) HandleSuccessfulPayment(ctx context.Context, message *tgbotapi.Message) error {
+	sp := message.SuccessfulPayment
+	event, err := m.transition(ctx, sp.InvoicePayload, StatePaid, sp.TelegramPaymentChargeID, func(p *Payment) (bool, error) {
+		if slices.Contains(p.TelegramPaymentChargeIDs, sp.TelegramPaymentChargeID) {
+			return true, nil
+		}
+		p.State = StatePaid
+		p.TelegramPaymentChargeIDs = append(p.TelegramPaymentChargeIDs, sp.TelegramPaymentChargeID)
+		p.ProviderPaymentChargeID = sp.ProviderPaymentChargeID
+		p.SubscriptionExpirationDate = sp.SubscriptionExpirationDate
+		p.Currency, p.PaidAmount = sp.Currency, sp.TotalAmount
+		if message.From != nil {
+			p.UserID = message.From.ID
+		}
+		if p.ChatID == 0 && message.Chat != nil {
+			p.ChatID = message.Chat.ID
+		}
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+	return m.emit(ctx, event)
+}
+
+// HandleRefundedPayment records the payment of message.RefundedPayment as StateRefunded.
+func (m *Manager) HandleRefundedPayment(ctx context.Context, message *tgbotapi.Message) error {
+	refunded := message.RefundedPayment
+	return m.refunded(ctx, refunded.InvoicePayload, refunded.TelegramPaymentChargeID)
+}
+
+// Refund refunds the last successful payment in Telegram Stars with the
+// invoice payload and records it as StateRefunded.
+func (m *Manager) Refund(ctx context.Context, payload string) error {
+	payment, err := m.store.Get(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("failed to get payment %q: %w", payload, err)
+	}
+	if payment.State != StatePaid {
+		return fmt.Errorf("payment %q is %s, not %s", payload, payment.State, StatePaid)
+	}
+	if payment.Currency != tgbotapi.CurrencyStars {
+		return fmt.Errorf("payment %q is in %s, only payments in Telegram Stars can be refunded by the bot", payload, payment.Currency)
+	}
+	chargeID := payment.TelegramPaymentChargeID()
+	config := &tgbotapi.RefundStarPaymentConfig{UserID: payment.UserID, TelegramPaymentChargeID: chargeID}
+	if _, err = m.bot.MakeRequestFromChattableContext(ctx, config); err != nil {
+		return err
+	}
+	return m.refunded(ctx, payload, chargeID)
+}
+
+func (m *Manager) refunded(ctx context.Context, payload, chargeID string) error {
+	event, err := m.transition(ctx, payload, StateRefunded, chargeID, func(p *Payment) (bool, error) {
+		if slices.Contains(p.RefundedChargeIDs, chargeID) {
+			return true, nil
+		}
+		p.State = StateRefunded
+		p.RefundedChargeIDs = append(p.RefundedChargeIDs, chargeID)
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+	return m.emit(ctx, event)
+}
+
+// transition saves the payment modified by change and returns the event of
+// the transition, a duplicate one if change reports it was already recorded.
+func (m *Manager) transition(ctx context.Context, payload string, state State, key string, change func(p *Payment) (bool, error)) (event Event, err error) {
+	event = Event{ID: eventID(state, key), State: state}
+	err = m.store.Update(ctx, payload, func(p *Payment) (err error) {
+		if event.Duplicate, err = change(p); err != nil {
+			return err
+		}
+		event.Payment = p.clone()
+		return nil
+	})
+	if err != nil {
+		return event, fmt.Errorf("failed to save %s payment %q: %w", state, payload, err)
+	}
+	return event, nil
+}
+
+func (m *Manager) emit(ctx context.Context, event Event) error {
+	if m.OnEvent == nil {
+		return nil
+	}
+	return m.OnEvent(ctx, event)
+}
+
+func eventID(state State, key string) string {
+	return string(state) + ":" + key
+}
@@ -0,0 +1,172 @@
+package tgpayments_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bots-go-framework/bots-api-telegram/tgbotapi"
+	"github.com/bots-go-framework/bots-api-telegram/tgbottest"
+	"github.com/bots-go-framework/bots-api-telegram/tgpayments"
+)
+
+func newStarsInvoice(payload string) *tgbotapi.InvoiceConfig {
+	return &tgbotapi.InvoiceConfig{
+		BaseChat:    tgbotapi.BaseChat{ChatID: 42},
+		Title:       "Pro",
+		Description: "Pro subscription",
+		Payload:     payload,
+		Currency:    tgbotapi.CurrencyStars,
+		Prices:      []tgbotapi.LabeledPrice{{Label: "Pro", Amount: 50}},
+	}
+}
+
+func preCheckout(id, payload string, amount int) tgbotapi.Update {
+	return tgbotapi.Update{PreCheckoutQuery: &tgbotapi.PreCheckoutQuery{
+		ID:             id,
+		From:           &tgbotapi.User{ID: 7},
+		Currency:       tgbotapi.CurrencyStars,
+		TotalAmount:    amount,
+		InvoicePayload: payload,
+	}}
+}
+
+func paymentMessage(payload, chargeID string, refunded bool) tgbotapi.Update {
+	payment := tgbotapi.Payment{Currency: tgbotapi.CurrencyStars, TotalAmount: 50, InvoicePayload: payload, TelegramPaymentChargeID: chargeID}
+	message := &tgbotapi.Message{From: &tgbotapi.User{ID: 7}, Chat: &tgbotapi.Chat{ID: 42}}
+	if refunded {
+		message.RefundedPayment = &tgbotapi.RefundedPayment{Payment: payment}
+	} else {
+		message.SuccessfulPayment = &tgbotapi.SuccessfulPayment{Payment: payment}
+	}
+	return tgbotapi.Update{Message: message}
+}
+
+func TestManager_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	srv := tgbottest.NewServer(t)
+	store := tgpayments.NewMemoryStore()
+	m := tgpayments.NewManager(srv.Bot(), store)
+	var events []tgpayments.Event
+	m.OnEvent = func(_ context.Context, event tgpayments.Event) error {
+		events = append(events, event)
+		return nil
+	}
+	m.Validate = func(_ context.Context, payment tgpayments.Payment, _ *tgbotapi.PreCheckoutQuery) error {
+		if payment.TotalAmount != 50 {
+			t.Errorf("validated %+v", payment)
+		}
+		return nil
+	}
+	d := tgbotapi.NewDispatcher(srv.Bot())
+	m.Register(d)
+
+	if _, err := m.SendInvoice(ctx, newStarsInvoice("order-1")); err != nil {
+		t.Fatal(err)
+	}
+	for _, update := range []tgbotapi.Update{
+		preCheckout("q1", "order-1", 50),
+		preCheckout("q1", "order-1", 50),
+		paymentMessage("order-1", "ch_1", false),
+		paymentMessage("order-1", "ch_1", false),
+	} {
+		if err := d.HandleUpdate(ctx, update); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls := srv.CallsTo("answerPreCheckoutQuery"); len(calls) != 1 || calls[0].Params.Get("ok") != "true" {
+		t.Fatalf("answerPreCheckoutQuery calls = %+v", calls)
+	}
+
+	if err := m.Refund(ctx, "order-1"); err != nil {
+		t.Fatal(err)
+	}
+	call, _ := srv.LastCall("refundStarPayment")
+	if call.Params.Get("user_id") != "7" || call.Params.Get("telegram_payment_charge_id") != "ch_1" {
+		t.Errorf("refundStarPayment params = %v", call.Params)
+	}
+	if err := d.HandleUpdate(ctx, paymentMessage("order-1", "ch_1", true)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		id        string
+		duplicate bool
+	}{
+		{"invoiced:order-1", false},
+		{"approved:q1", false},
+		{"approved:q1", true},
+		{"paid:ch_1", false},
+		{"paid:ch_1", true},
+		{"refunded:ch_1", false},
+		{"refunded:ch_1", true},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		if events[i].ID != w.id || events[i].Duplicate != w.duplicate {
+			t.Errorf("event %d = %s (duplicate %v), want %s (duplicate %v)", i, events[i].ID, events[i].Duplicate, w.id, w.duplicate)
+		}
+	}
+
+	payment, err := store.Get(ctx, "order-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payment.State != tgpayments.StateRefunded || payment.UserID != 7 || payment.TelegramPaymentChargeID() != "ch_1" || len(payment.TelegramPaymentChargeIDs) != 1 {
+		t.Errorf("payment = %+v", payment)
+	}
+	if _, err = m.SendInvoice(ctx, newStarsInvoice("order-1")); !errors.Is(err, tgpayments.ErrPayloadInUse) {
+		t.Errorf("SendInvoice of a refunded payload: err = %v", err)
+	}
+}
+
+func TestManager_RejectsPreCheckout(t *testing.T) {
+	ctx := context.Background()
+	srv := tgbottest.NewServer(t)
+	m := tgpayments.NewManager(srv.Bot(), tgpayments.NewMemoryStore())
+	m.PreCheckoutTimeout = 10 * time.Millisecond
+	release := make(chan struct{})
+	defer close(release)
+	m.Validate = func(ctx context.Context, payment tgpayments.Payment, _ *tgbotapi.PreCheckoutQuery) error {
+		switch payment.Payload {
+		case "sold-out":
+			return tgpayments.Reject("Sold out")
+		case "slow":
+			<-release // ignores ctx
+		}
+		return nil
+	}
+	for _, payload := range []string{"sold-out", "slow", "changed"} {
+		if _, err := m.SendInvoice(ctx, newStarsInvoice(payload)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tt := range []struct {
+		update  tgbotapi.Update
+		message string
+		wantErr bool
+	}{
+		{update: preCheckout("q1", "sold-out", 50), message: "Sold out"},
+		{update: preCheckout("q2", "slow", 50), message: tgpayments.DefaultErrorMessage, wantErr: true},
+		{update: preCheckout("q3", "changed", 40), message: tgpayments.ChangedInvoiceMessage},
+		{update: preCheckout("q4", "unknown", 50), message: tgpayments.UnknownInvoiceMessage},
+	} {
+		query := tt.update.PreCheckoutQuery
+		started := time.Now()
+		err := m.HandleUpdate(ctx, tt.update)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v", query.InvoicePayload, err)
+		}
+		if elapsed := time.Since(started); elapsed > time.Second {
+			t.Errorf("%s: answered after %v", query.InvoicePayload, elapsed)
+		}
+		call, _ := srv.LastCall("answerPreCheckoutQuery")
+		if call.Params.Get("pre_checkout_query_id") != query.ID || call.Params.Get("ok") != "false" || call.Params.Get("error_message") != tt.message {
+			t.Errorf("%s: answer = %v", query.InvoicePayload, call.Params)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package tgpayments
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrNotFound is returned by Store.Get for an unknown invoice payload.
+var ErrNotFound = errors.New("payment not found")
+
+// Store persists payments by invoice payload.
+//
+// Handlers may be called concurrently, e.g. for a webhook update delivered
+// twice, so Update must apply the function atomically, for example in a
+// database transaction.
+type Store interface {
+	// Get returns the payment with the invoice payload or an error wrapping ErrNotFound.
+	Get(ctx context.Context, payload string) (Payment, error)
+
+	// Update calls update with the payment with the invoice payload, or a
+	// Payment with only the Payload set if there is none, and saves the
+	// modified payment unless update returns an error, which is returned.
+	Update(ctx context.Context, payload string, update func(payment *Payment) error) error
+}
+
+// MemoryStore is a Store keeping payments in memory, for tests and bots
+// that don't need to survive a restart.
+type MemoryStore struct {
+	mu       sync.Mutex
+	payments map[string]Payment
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{payments: make(map[string]Payment)}
+}
+
+// Get returns a copy of the payment with the invoice payload.
+func (s *MemoryStore) Get(_ context.Context, payload string) (Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payment, ok := s.payments[payload]
+	if !ok {
+		return Payment{}, ErrNotFound
+	}
+	return payment.clone(), nil
+}
+
+// Update applies update to a copy of the payment under a lock.
+func (s *MemoryStore) Update(_ context.Context, payload string, update func(payment *Payment) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payment, ok := s.payments[payload]
+	if ok {
+		payment = payment.clone()
+	} else {
+		payment.Payload = payload
+	}
+	if err := update(&payment); err != nil {
+		return err
+	}
+	s.payments[payload] = payment
+	return nil
+}
+
+func (p Payment) clone() Payment {
+	p.TelegramPaymentChargeIDs = slices.Clone(p.TelegramPaymentChargeIDs)
+	p.RefundedChargeIDs = slices.Clone(p.RefundedChargeIDs)
+	return p
+}